@@ -9,13 +9,24 @@ import (
 	"time"
 
 	"github.com/your-username/go-clean-architecture/config"
-	_ "github.com/your-username/go-clean-architecture/docs"
+	"github.com/your-username/go-clean-architecture/internal/dto"
 	"github.com/your-username/go-clean-architecture/internal/handler"
 	"github.com/your-username/go-clean-architecture/internal/repository"
 	"github.com/your-username/go-clean-architecture/internal/router"
 	"github.com/your-username/go-clean-architecture/internal/usecase"
+	"github.com/your-username/go-clean-architecture/pkg/cache"
 	"github.com/your-username/go-clean-architecture/pkg/database"
+	"github.com/your-username/go-clean-architecture/pkg/events"
+	"github.com/your-username/go-clean-architecture/pkg/lifecycle"
 	"github.com/your-username/go-clean-architecture/pkg/logger"
+	"github.com/your-username/go-clean-architecture/pkg/mail"
+	"github.com/your-username/go-clean-architecture/pkg/pagination"
+	"github.com/your-username/go-clean-architecture/pkg/permission"
+	"github.com/your-username/go-clean-architecture/pkg/realtime"
+	"github.com/your-username/go-clean-architecture/pkg/response"
+	"github.com/your-username/go-clean-architecture/pkg/scheduler"
+	"github.com/your-username/go-clean-architecture/pkg/storage"
+	"github.com/your-username/go-clean-architecture/pkg/tracing"
 	"github.com/your-username/go-clean-architecture/pkg/utils"
 	"github.com/your-username/go-clean-architecture/pkg/validator"
 )
@@ -38,29 +49,96 @@ import (
 // @name Authorization
 // @description Type "Bearer" followed by a space and JWT token.
 
+// configPath is the base config file, see config.LoadConfig
+const configPath = ".env"
+
 func main() {
 	// Initialize logger
 	logger.InitLogger(true)
 	logger.Info("Starting application...")
 
 	// Load configuration
-	cfg, err := config.LoadConfig(".env")
+	cfg, err := config.LoadConfig(configPath)
 	if err != nil {
 		logger.Fatalf("Failed to load config: %v", err)
 	}
 
+	// Validate configuration before starting anything
+	if err := cfg.Validate(); err != nil {
+		logger.Fatalf("%v", err)
+	}
+
 	// Initialize logger with config
-	logger.InitLogger(cfg.App.Debug)
+	logger.InitLoggerWithConfig(&cfg.Log)
+	defer logger.Close()
+
+	// Apply settings that can change without a restart when the config
+	// file is edited
+	configHolder := config.NewHolder(cfg, configPath)
+	configHolder.Subscribe(func(next *config.Config) {
+		if err := logger.SetLevel(next.Log.Level); err != nil {
+			logger.Warnf("Invalid log level %q ignored: %v", next.Log.Level, err)
+		}
+	})
+	configHolder.Subscribe(func(next *config.Config) {
+		permission.SetFeatureFlags(next.FeatureFlags)
+	})
+	configHolder.Subscribe(func(next *config.Config) {
+		pagination.SetLimits(next.Pagination)
+	})
+	if cfg.App.WatchConfig {
+		configHolder.Watch()
+	}
 
 	// Register custom validator
-	validator.RegisterGinValidator()
+	validator.SetPasswordPolicy(cfg.Password)
+	utils.SetPasswordCost(cfg.Password.BcryptCost)
+	permission.SetFeatureFlags(cfg.FeatureFlags)
+	pagination.SetLimits(cfg.Pagination)
+	response.SetErrorFormat(cfg.Response.ErrorFormat)
+	if err := response.SetLinkOrigin(cfg.App.PublicURL, cfg.App.TrustedProxies); err != nil {
+		logger.Fatalf("Invalid pagination link settings: %v", err)
+	}
+	dto.SetTimestampFormat(cfg.Response.TimestampFormat)
+	handler.SetStrictBinding(cfg.App.StrictBinding)
+	validator.RegisterGinValidator(dto.RegisterValidation)
+
+	// Components register shutdown hooks as they start; hooks run in reverse
+	// order, so later components stop before the ones they depend on
+	shutdown := lifecycle.New()
+
+	// Initialize tracing, a no-op unless an OTLP endpoint is configured. It
+	// stops last so spans from the other shutdown hooks are still exported.
+	shutdownTracing, err := tracing.Init(context.Background(), cfg.Tracing, cfg.App.Env)
+	if err != nil {
+		logger.Fatalf("Failed to initialize tracing: %v", err)
+	}
+	shutdown.OnShutdown("tracing", 5*time.Second, shutdownTracing)
 
 	// Connect to database
 	db, err := database.NewDatabase(&cfg.Database)
 	if err != nil {
 		logger.Fatalf("Failed to connect to database: %v", err)
 	}
-	defer db.Close()
+	shutdown.OnShutdownClose("database", 5*time.Second, db.Close)
+	if cfg.Database.RunMigrations {
+		if err := db.RunMigrations(context.Background()); err != nil {
+			logger.Fatalf("Failed to run migrations: %v", err)
+		}
+	}
+	if cfg.Tracing.Enabled() {
+		if err := database.RegisterTracing(db.DB); err != nil {
+			logger.Fatalf("Failed to register database tracing: %v", err)
+		}
+	}
+	if cfg.Database.Metrics.Enabled {
+		if err := database.RegisterMetrics(db.DB, cfg.Database.Metrics.TableLabel); err != nil {
+			logger.Fatalf("Failed to register database metrics: %v", err)
+		}
+		if interval := cfg.Database.Metrics.PoolStatsInterval; interval > 0 {
+			shutdown.OnShutdown("database pool stats", time.Second, db.StartPoolStatsSampler(interval))
+		}
+	}
 
 	// Connect to Redis
 	redis, err := database.NewRedisClient(&cfg.Redis)
@@ -68,39 +146,105 @@ func main() {
 		logger.Warnf("Failed to connect to Redis: %v", err)
 		// Continue without Redis, it's optional
 	} else {
-		defer redis.Close()
+		shutdown.OnShutdownClose("redis", 5*time.Second, redis.Close)
 	}
 
+	// Initialize the application cache
+	appCache, err := cache.NewCache(&cfg.Cache, redis)
+	if err != nil {
+		logger.Fatalf("Failed to initialize cache: %v", err)
+	}
+
+	// Initialize the event broker behind /api/v1/events
+	eventBroker := realtime.NewBroker(redis)
+	shutdown.OnShutdownClose("event broker", 5*time.Second, eventBroker.Close)
+
 	// Initialize JWT Manager
-	jwtManager := utils.NewJWTManager(cfg.JWT.Secret, cfg.JWT.ExpireHours)
+	jwtManager := utils.NewJWTManager(cfg.JWT)
+
+	// Initialize file storage
+	fileStorage, err := storage.NewStorage(&cfg.Storage)
+	if err != nil {
+		logger.Fatalf("Failed to initialize storage: %v", err)
+	}
 
 	// Initialize repositories
-	userRepo := repository.NewUserRepository(db.DB)
+	userRepo := repository.NewUserRepository(db.DB, db, cfg.Database.QueryTimeout, cfg.Users.DeleteMode == config.DeleteModeHard)
+	auditLogRepo := repository.NewAuditLogRepository(db.DB)
+
+	// Register validators that need database access
+	validator.RegisterDBValidators(userRepo.ExistsByEmail)
 
 	// Initialize use cases
-	userUseCase := usecase.NewUserUseCase(userRepo, jwtManager)
+	auditUseCase := usecase.NewAuditUseCase(auditLogRepo)
+	shutdown.OnShutdown("audit log", 5*time.Second, auditUseCase.Close)
+	// Side effects of the use cases subscribe to the events they publish. The
+	// bus stops first, so its handlers can still record audit entries and
+	// queue emails.
+	eventBus := events.NewBus()
+	usecase.RegisterSubscribers(eventBus, auditUseCase, eventBroker)
+	if cfg.Mail.WelcomeEnabled {
+		sender, err := mail.NewMailer(cfg)
+		if err != nil {
+			logger.Fatalf("Failed to initialize mailer: %v", err)
+		}
+		mailQueue := mail.NewQueueMailer(sender, &cfg.MailQueue)
+		shutdown.OnShutdown("mail queue", 10*time.Second, mailQueue.Shutdown)
+		mailer, err := mail.NewTemplateMailer(mailQueue)
+		if err != nil {
+			logger.Fatalf("Failed to initialize mail templates: %v", err)
+		}
+		usecase.SubscribeWelcomeEmail(eventBus, mailer, cfg.App.Name)
+	}
+	shutdown.OnShutdown("event bus", 5*time.Second, eventBus.Close)
+	userUseCase := usecase.NewUserUseCase(userRepo, jwtManager, auditUseCase, fileStorage, cfg.Avatar, cfg.Users, appCache, cfg.Cache.UserTTL, eventBus)
+
+	// Start periodic jobs
+	if cfg.Scheduler.Enabled {
+		jobs := scheduler.New(cfg.Scheduler, redis)
+		jobs.Register("purge deleted users", cfg.Scheduler.PurgeDeletedUsersInterval, func(ctx context.Context) error {
+			return userUseCase.PurgeDeleted(ctx, cfg.Scheduler.PurgeDeletedUsersAfter)
+		})
+		jobs.Start()
+		shutdown.OnShutdown("scheduler", 30*time.Second, jobs.Stop)
+	}
 
 	// Initialize handlers
 	userHandler := handler.NewUserHandler(userUseCase)
-	healthHandler := handler.NewHealthHandler()
+	auditLogHandler := handler.NewAuditLogHandler(auditUseCase)
+	healthHandler := handler.NewHealthHandler(db)
+	drain := lifecycle.NewDrain()
+	eventHandler := handler.NewEventHandler(eventBroker, cfg.Events.Heartbeat, drain.ShutdownCtx())
 
 	// Initialize router
-	r := router.NewRouter(userHandler, healthHandler, jwtManager, cfg.App.Debug)
+	r := router.NewRouter(userHandler, auditLogHandler, healthHandler, eventHandler, jwtManager, userUseCase, drain, redis, cfg)
 	engine := r.SetupRoutes()
 
 	// Create HTTP server
 	server := &http.Server{
 		Addr:         ":" + cfg.App.Port,
-		Handler:      engine,
+		Handler:      drain.Track(engine),
 		ReadTimeout:  15 * time.Second,
 		WriteTimeout: 15 * time.Second,
 		IdleTimeout:  60 * time.Second,
 	}
 
+	// Shutdown stops accepting connections, then waits for in-flight requests.
+	// Draining starts once the listeners are closed, so event streams and
+	// other long-lived handlers wind down instead of being cut off.
+	server.RegisterOnShutdown(drain.Start)
+	shutdown.OnShutdown("http server", 10*time.Second, func(ctx context.Context) error {
+		err := server.Shutdown(ctx)
+		if err != nil && ctx.Err() != nil {
+			logger.Warnf("Shutdown deadline reached with %d requests still in flight", drain.InFlight())
+		}
+		return err
+	})
+
 	// Start server in goroutine
 	go func() {
 		logger.Infof("Server is running on port %s", cfg.App.Port)
-		logger.Infof("Swagger documentation available at http://localhost:%s/swagger/index.html", cfg.App.Port)
+		logger.Infof("Swagger documentation available at http://localhost:%s%s/swagger/index.html", cfg.App.Port, cfg.App.BasePath)
 		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 			logger.Fatalf("Failed to start server: %v", err)
 		}
@@ -111,13 +255,19 @@ func main() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
-	logger.Info("Shutting down server...")
+	// Fail readiness and turn away new requests while the load balancer
+	// notices, then stop
+	drain.BeginShutdown()
+	if cfg.App.ShutdownDelay > 0 {
+		logger.Infof("Shutdown signal received, draining traffic for %s", cfg.App.ShutdownDelay)
+		time.Sleep(cfg.App.ShutdownDelay)
+	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	logger.Info("Shutting down server...")
 
-	if err := server.Shutdown(ctx); err != nil {
-		logger.Fatalf("Server forced to shutdown: %v", err)
+	if err := shutdown.Shutdown(context.Background()); err != nil {
+		logger.Errorf("Shutdown completed with errors: %v", err)
+		return
 	}
 
 	logger.Info("Server exited properly")
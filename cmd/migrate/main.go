@@ -6,6 +6,12 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database/postgres"
@@ -14,13 +20,29 @@ import (
 	"github.com/spf13/viper"
 )
 
+// migrationsDir is where migration files live
+const migrationsDir = "database/migrations"
+
+// migrationNamePattern accepts snake_case migration names
+var migrationNamePattern = regexp.MustCompile(`^[a-z0-9]+(_[a-z0-9]+)*$`)
+
 func main() {
 	// Parse command line flags
-	direction := flag.String("direction", "up", "Migration direction: up, down, force, version")
+	direction := flag.String("direction", "up", "Migration direction: up, down, force, version, create")
 	steps := flag.Int("steps", 0, "Number of migrations to run (0 = all)")
 	forceVersion := flag.Int("force", -1, "Force migration to a specific version")
+	name := flag.String("name", "", "Name of the migration to create, in snake_case")
+	seq := flag.Bool("seq", true, "Number created migrations sequentially instead of by timestamp")
 	flag.Parse()
 
+	// Creating files does not need a database connection
+	if *direction == "create" {
+		if err := createMigration(*name, *seq); err != nil {
+			log.Fatalf("Failed to create migration: %v", err)
+		}
+		os.Exit(0)
+	}
+
 	// Load config
 	viper.SetConfigFile(".env")
 	viper.AutomaticEnv()
@@ -54,7 +76,7 @@ func main() {
 
 	// Create migration instance
 	m, err := migrate.NewWithDatabaseInstance(
-		"file://database/migrations",
+		"file://"+migrationsDir,
 		"postgres",
 		driver,
 	)
@@ -99,3 +121,73 @@ func main() {
 
 	fmt.Println("Migration completed successfully!")
 }
+
+// createMigration writes empty up and down files for a new migration, using
+// golang-migrate's {version}_{name}.{up|down}.sql naming
+func createMigration(name string, seq bool) error {
+	if !migrationNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid name %q, use snake_case such as add_avatar_to_users", name)
+	}
+
+	version := time.Now().UTC().Format("20060102150405")
+	if seq {
+		next, err := nextSequenceVersion()
+		if err != nil {
+			return err
+		}
+		version = fmt.Sprintf("%06d", next)
+	}
+
+	if err := os.MkdirAll(migrationsDir, 0o755); err != nil {
+		return err
+	}
+
+	paths := []string{
+		filepath.Join(migrationsDir, fmt.Sprintf("%s_%s.up.sql", version, name)),
+		filepath.Join(migrationsDir, fmt.Sprintf("%s_%s.down.sql", version, name)),
+	}
+	for _, path := range paths {
+		if _, err := os.Stat(path); err == nil {
+			return fmt.Errorf("%s already exists", path)
+		}
+	}
+
+	for _, path := range paths {
+		// O_EXCL still refuses to overwrite a file created in the meantime
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+		if err != nil {
+			return err
+		}
+		if err := file.Close(); err != nil {
+			return err
+		}
+		fmt.Printf("Created %s\n", path)
+	}
+
+	return nil
+}
+
+// nextSequenceVersion returns one past the highest existing migration version
+func nextSequenceVersion() (uint64, error) {
+	entries, err := os.ReadDir(migrationsDir)
+	if err != nil && !os.IsNotExist(err) {
+		return 0, err
+	}
+
+	versions := make([]uint64, 0, len(entries))
+	for _, entry := range entries {
+		prefix, _, found := strings.Cut(entry.Name(), "_")
+		if !found {
+			continue
+		}
+		if version, err := strconv.ParseUint(prefix, 10, 64); err == nil {
+			versions = append(versions, version)
+		}
+	}
+	if len(versions) == 0 {
+		return 1, nil
+	}
+
+	sort.Slice(versions, func(i, j int) bool { return versions[i] < versions[j] })
+	return versions[len(versions)-1] + 1, nil
+}
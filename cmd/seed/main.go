@@ -1,6 +1,10 @@
 package main
 
 import (
+	"context"
+	"flag"
+	"strings"
+
 	"github.com/your-username/go-clean-architecture/config"
 	dbseeder "github.com/your-username/go-clean-architecture/database/seeder"
 	"github.com/your-username/go-clean-architecture/internal/entity"
@@ -9,6 +13,11 @@ import (
 )
 
 func main() {
+	// Parse command line flags
+	only := flag.String("only", "", "Comma-separated seeders to run, e.g. users (default: all)")
+	fresh := flag.Bool("fresh", false, "Truncate the seeded tables before seeding (local development only)")
+	flag.Parse()
+
 	// Initialize logger
 	logger.InitLogger(true)
 	logger.Info("Starting database seeder...")
@@ -26,14 +35,33 @@ func main() {
 	}
 	defer db.Close()
 
-	// Auto migrate
-	if err := db.AutoMigrate(&entity.User{}); err != nil {
+	// Apply the SQL migrations when enabled, otherwise auto migrate
+	if cfg.Database.RunMigrations {
+		if err := db.RunMigrations(context.Background()); err != nil {
+			logger.Fatalf("Failed to run migrations: %v", err)
+		}
+	} else if err := db.AutoMigrate(&entity.User{}, &entity.AuditLog{}); err != nil {
 		logger.Fatalf("Failed to auto migrate: %v", err)
 	}
 
-	// Run seeder
-	s := dbseeder.NewSeeder(db.DB)
-	if err := s.Seed(); err != nil {
+	var names []string
+	if *only != "" {
+		names = strings.Split(*only, ",")
+	}
+
+	runner := dbseeder.NewRunner(db.DB)
+
+	if *fresh {
+		if cfg.App.Env == "production" {
+			logger.Fatal("Refusing to truncate tables in production")
+		}
+		if err := runner.Truncate(names...); err != nil {
+			logger.Fatalf("Failed to truncate tables: %v", err)
+		}
+	}
+
+	// Run seeders
+	if err := runner.Seed(names...); err != nil {
 		logger.Fatalf("Failed to seed database: %v", err)
 	}
 
@@ -0,0 +1,112 @@
+package config
+
+import (
+	"os"
+	"reflect"
+	"slices"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+// Holder holds the current configuration and notifies subscribers when the
+// config file is reloaded. Components that can apply a setting live, such as
+// the log level, subscribe to it; everything else keeps its startup value.
+type Holder struct {
+	mu          sync.RWMutex
+	config      *Config
+	path        string
+	subscribers []func(*Config)
+}
+
+// NewHolder creates a holder for cfg, loaded from path
+func NewHolder(cfg *Config, path string) *Holder {
+	return &Holder{config: cfg, path: path}
+}
+
+// Get returns the current configuration, which must not be modified
+func (h *Holder) Get() *Config {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.config
+}
+
+// Subscribe registers fn to be called with the new configuration after each
+// reload
+func (h *Holder) Subscribe(fn func(*Config)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.subscribers = append(h.subscribers, fn)
+}
+
+// Watch reloads the configuration whenever the config file changes. viper
+// watches the most specific file loaded, the overlay when there is one. It
+// is a no-op when configuration comes from the environment only.
+func (h *Holder) Watch() {
+	file := viper.ConfigFileUsed()
+	if _, err := os.Stat(file); err != nil {
+		return
+	}
+
+	viper.OnConfigChange(func(fsnotify.Event) {
+		h.reload()
+	})
+	viper.WatchConfig()
+	logrus.Infof("Watching %s for configuration changes", file)
+}
+
+// reload re-reads the config files and publishes the result. An invalid
+// configuration is rejected and the current one kept.
+func (h *Holder) reload() {
+	if err := readConfigFiles(h.path); err != nil {
+		logrus.Errorf("Config reload failed, keeping current settings: %v", err)
+		return
+	}
+	next := buildConfig()
+	if err := next.Validate(); err != nil {
+		logrus.Errorf("Config reload failed, keeping current settings: %v", err)
+		return
+	}
+
+	h.mu.Lock()
+	for _, name := range keepRestartSettings(h.config, next) {
+		logrus.Warnf("%s changed on reload, restart to apply", name)
+	}
+	h.config = next
+	subscribers := slices.Clone(h.subscribers)
+	h.mu.Unlock()
+
+	for _, fn := range subscribers {
+		fn(next)
+	}
+	logrus.Info("Configuration reloaded")
+}
+
+// keepRestartSettings copies the settings that only take effect on restart
+// from current into next, and returns the names of those that changed
+func keepRestartSettings(current, next *Config) []string {
+	var changed []string
+	if current.App.Port != next.App.Port {
+		changed = append(changed, "APP_PORT")
+		next.App.Port = current.App.Port
+	}
+	if current.App.Env != next.App.Env {
+		changed = append(changed, "APP_ENV")
+		next.App.Env = current.App.Env
+	}
+	if !reflect.DeepEqual(current.Database, next.Database) {
+		changed = append(changed, "database settings")
+		next.Database = current.Database
+	}
+	if !reflect.DeepEqual(current.Redis, next.Redis) {
+		changed = append(changed, "Redis settings")
+		next.Redis = current.Redis
+	}
+	if !reflect.DeepEqual(current.JWT, next.JWT) {
+		changed = append(changed, "JWT settings")
+		next.JWT = current.JWT
+	}
+	return changed
+}
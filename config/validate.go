@@ -0,0 +1,219 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// minProductionJWTSecretLength is the minimum JWT secret length in production
+const minProductionJWTSecretLength = 32
+
+// Validate checks critical configuration values and reports every problem found
+func (c *Config) Validate() error {
+	var problems []string
+
+	// JWT
+	if c.JWT.Secret == "" {
+		problems = append(problems, "JWT_SECRET is required")
+	} else if c.App.Env == "production" && len(c.JWT.Secret) < minProductionJWTSecretLength {
+		problems = append(problems, fmt.Sprintf("JWT_SECRET must be at least %d characters in production", minProductionJWTSecretLength))
+	}
+	for i, previous := range c.JWT.PreviousSecrets {
+		if previous == c.JWT.Secret {
+			problems = append(problems, fmt.Sprintf("JWT_PREVIOUS_SECRETS entry %d repeats JWT_SECRET", i+1))
+		} else if c.App.Env == "production" && len(previous) < minProductionJWTSecretLength {
+			problems = append(problems, fmt.Sprintf("JWT_PREVIOUS_SECRETS entry %d must be at least %d characters in production", i+1, minProductionJWTSecretLength))
+		}
+	}
+	if c.JWT.ExpireHours <= 0 {
+		problems = append(problems, "JWT_EXPIRE_HOURS must be positive")
+	}
+	if c.JWT.Leeway < 0 {
+		problems = append(problems, "JWT_LEEWAY_SECONDS must not be negative")
+	}
+	if c.JWT.FreshAuthMaxAge <= 0 {
+		problems = append(problems, "JWT_FRESH_AUTH_MAX_AGE_SECONDS must be positive")
+	}
+
+	// App
+	if port, err := strconv.Atoi(c.App.Port); err != nil || port < 1 || port > 65535 {
+		problems = append(problems, fmt.Sprintf("APP_PORT must be a number between 1 and 65535, got %q", c.App.Port))
+	}
+
+	if c.App.BasePath != "" && !strings.HasPrefix(c.App.BasePath, "/") {
+		problems = append(problems, fmt.Sprintf("API_BASE_PATH must start with /, got %q", c.App.BasePath))
+	}
+	if c.App.PublicURL != "" {
+		if u, err := url.Parse(c.App.PublicURL); err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+			problems = append(problems, fmt.Sprintf("APP_PUBLIC_URL must be an absolute http or https URL, got %q", c.App.PublicURL))
+		}
+	}
+	if c.App.ShutdownDelay < 0 {
+		problems = append(problems, "APP_SHUTDOWN_DELAY_SECONDS must not be negative")
+	}
+	switch c.App.StrictBinding {
+	case StrictBindingOff, StrictBindingRoutes, StrictBindingAll:
+	default:
+		problems = append(problems, fmt.Sprintf("APP_STRICT_BINDING must be %s, %s or %s, got %q", StrictBindingOff, StrictBindingRoutes, StrictBindingAll, c.App.StrictBinding))
+	}
+	switch c.App.OpenAPIValidation {
+	case OpenAPIValidationOff, OpenAPIValidationLog, OpenAPIValidationReject:
+	default:
+		problems = append(problems, fmt.Sprintf("OPENAPI_VALIDATION must be %s, %s or %s, got %q", OpenAPIValidationOff, OpenAPIValidationLog, OpenAPIValidationReject, c.App.OpenAPIValidation))
+	}
+	for _, proxy := range c.App.TrustedProxies {
+		if _, _, err := net.ParseCIDR(proxy); err != nil && net.ParseIP(proxy) == nil {
+			problems = append(problems, fmt.Sprintf("TRUSTED_PROXIES must list IPs or CIDRs, got %q", proxy))
+		}
+	}
+
+	// Users
+	switch c.Users.DeleteMode {
+	case DeleteModeSoft, DeleteModeHard:
+	default:
+		problems = append(problems, fmt.Sprintf("DELETE_MODE must be %s or %s, got %q", DeleteModeSoft, DeleteModeHard, c.Users.DeleteMode))
+	}
+
+	// Password
+	if c.Password.BcryptCost < bcrypt.MinCost || c.Password.BcryptCost > bcrypt.MaxCost {
+		problems = append(problems, fmt.Sprintf("BCRYPT_COST must be between %d and %d, got %d", bcrypt.MinCost, bcrypt.MaxCost, c.Password.BcryptCost))
+	}
+
+	// Database
+	required := []struct{ key, value string }{
+		{"DB_NAME", c.Database.DBName},
+	}
+	switch c.Database.Driver {
+	case DBDriverPostgres:
+		required = append(required, []struct{ key, value string }{
+			{"DB_HOST", c.Database.Host},
+			{"DB_PORT", c.Database.Port},
+			{"DB_USER", c.Database.User},
+		}...)
+	case DBDriverSQLite:
+		if len(c.Database.Replicas) > 0 {
+			problems = append(problems, "DB_REPLICAS is only supported with DB_DRIVER=postgres")
+		}
+		if c.Database.RunMigrations {
+			problems = append(problems, "DB_RUN_MIGRATIONS is only supported with DB_DRIVER=postgres")
+		}
+	default:
+		problems = append(problems, fmt.Sprintf("DB_DRIVER must be %s or %s, got %q", DBDriverPostgres, DBDriverSQLite, c.Database.Driver))
+	}
+	if c.Database.Retry.MaxAttempts < 1 {
+		problems = append(problems, "DB_RETRY_MAX_ATTEMPTS must be at least 1")
+	}
+	if c.Database.Metrics.PoolStatsInterval < 0 {
+		problems = append(problems, "DB_POOL_STATS_INTERVAL_SECONDS must not be negative")
+	}
+	for _, field := range required {
+		if field.value == "" {
+			problems = append(problems, field.key+" is required")
+		}
+	}
+
+	// Redis
+	if c.Redis.URL != "" {
+		if u, err := url.Parse(c.Redis.URL); err != nil || (u.Scheme != "redis" && u.Scheme != "rediss") {
+			problems = append(problems, "REDIS_URL must be a redis:// or rediss:// URL")
+		}
+	}
+	if c.Redis.PoolSize < 1 {
+		problems = append(problems, "REDIS_POOL_SIZE must be positive")
+	}
+	if c.Redis.MinIdleConns < 0 || c.Redis.MinIdleConns > c.Redis.PoolSize {
+		problems = append(problems, fmt.Sprintf("REDIS_MIN_IDLE_CONNS must be between 0 and REDIS_POOL_SIZE, got %d", c.Redis.MinIdleConns))
+	}
+	if c.Redis.MaxRetries < 0 {
+		problems = append(problems, "REDIS_MAX_RETRIES must not be negative")
+	}
+	for _, timeout := range []struct {
+		key   string
+		value time.Duration
+	}{
+		{"REDIS_TIMEOUT_SECONDS", c.Redis.Timeout},
+		{"REDIS_DIAL_TIMEOUT_SECONDS", c.Redis.DialTimeout},
+		{"REDIS_READ_TIMEOUT_SECONDS", c.Redis.ReadTimeout},
+		{"REDIS_WRITE_TIMEOUT_SECONDS", c.Redis.WriteTimeout},
+	} {
+		if timeout.value < 0 {
+			problems = append(problems, timeout.key+" must not be negative")
+		}
+	}
+
+	// SMTP
+	for _, smtp := range c.SMTPProviders() {
+		if smtp.Host == "" {
+			continue
+		}
+		switch smtp.TLSMode {
+		case SMTPTLSAuto, SMTPTLSImplicit, SMTPTLSStartTLS:
+		default:
+			problems = append(problems, fmt.Sprintf("SMTP TLS mode for %s must be %s, %s or %s, got %q", smtp.Host, SMTPTLSAuto, SMTPTLSImplicit, SMTPTLSStartTLS, smtp.TLSMode))
+		}
+		if _, ok := smtp.TLSVersion(); !ok {
+			problems = append(problems, fmt.Sprintf("SMTP TLS minimum version for %s must be 1.0, 1.1, 1.2 or 1.3, got %q", smtp.Host, smtp.TLSMinVersion))
+		}
+		if smtp.TLSSkipVerify && c.App.Env == "production" {
+			problems = append(problems, fmt.Sprintf("SMTP TLS certificate verification for %s cannot be disabled in production", smtp.Host))
+		}
+	}
+
+	// Tracing
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		problems = append(problems, fmt.Sprintf("OTEL_TRACES_SAMPLER_ARG must be between 0 and 1, got %g", c.Tracing.SampleRatio))
+	}
+
+	// Pagination
+	if c.Pagination.DefaultPage < 1 {
+		problems = append(problems, "PAGINATION_DEFAULT_PAGE must be positive")
+	}
+	if c.Pagination.MaxLimit < 1 {
+		problems = append(problems, "PAGINATION_MAX_LIMIT must be positive")
+	}
+	if c.Pagination.DefaultLimit < 1 || c.Pagination.DefaultLimit > c.Pagination.MaxLimit {
+		problems = append(problems, fmt.Sprintf("PAGINATION_DEFAULT_LIMIT must be between 1 and PAGINATION_MAX_LIMIT, got %d", c.Pagination.DefaultLimit))
+	}
+
+	// Response
+	switch c.Response.ErrorFormat {
+	case ErrorFormatEnvelope, ErrorFormatJSONAPI:
+	default:
+		problems = append(problems, fmt.Sprintf("RESPONSE_ERROR_FORMAT must be %s or %s, got %q", ErrorFormatEnvelope, ErrorFormatJSONAPI, c.Response.ErrorFormat))
+	}
+	switch c.Response.TimestampFormat {
+	case TimestampFormatRFC3339, TimestampFormatUnix, TimestampFormatUnixMillis:
+	default:
+		problems = append(problems, fmt.Sprintf("RESPONSE_TIMESTAMP_FORMAT must be %s, %s or %s, got %q", TimestampFormatRFC3339, TimestampFormatUnix, TimestampFormatUnixMillis, c.Response.TimestampFormat))
+	}
+
+	// Events
+	if c.Events.Heartbeat <= 0 {
+		problems = append(problems, "EVENTS_HEARTBEAT_SECONDS must be positive")
+	}
+
+	// Scheduler
+	if c.Scheduler.PurgeDeletedUsersInterval <= 0 {
+		problems = append(problems, "JOB_PURGE_DELETED_USERS_INTERVAL_SECONDS must be positive")
+	}
+	if c.Scheduler.PurgeDeletedUsersAfter <= 0 {
+		problems = append(problems, "JOB_PURGE_DELETED_USERS_AFTER_DAYS must be positive")
+	}
+	if c.Scheduler.LockTTL <= 0 {
+		problems = append(problems, "SCHEDULER_LOCK_TTL_SECONDS must be positive")
+	}
+	if c.Scheduler.LockRenewInterval <= 0 || c.Scheduler.LockRenewInterval >= c.Scheduler.LockTTL {
+		problems = append(problems, "SCHEDULER_LOCK_RENEW_SECONDS must be positive and less than SCHEDULER_LOCK_TTL_SECONDS")
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid configuration: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,4 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'active';
+UPDATE users SET status = CASE WHEN COALESCE(is_active, true) THEN 'active' ELSE 'suspended' END;
+CREATE INDEX IF NOT EXISTS idx_users_status ON users(status);
+ALTER TABLE users DROP COLUMN IF EXISTS is_active;
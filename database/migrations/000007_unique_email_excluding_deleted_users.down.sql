@@ -0,0 +1,3 @@
+-- Fails while a deleted and a remaining user share an email, purge one first
+DROP INDEX IF EXISTS idx_users_email_active;
+ALTER TABLE users ADD CONSTRAINT users_email_key UNIQUE (email);
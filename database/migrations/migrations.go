@@ -0,0 +1,9 @@
+// Package migrations embeds the SQL migrations so they ship with every binary
+package migrations
+
+import "embed"
+
+// FS holds the golang-migrate {version}_{name}.{up|down}.sql files
+//
+//go:embed *.sql
+var FS embed.FS
@@ -1,64 +1,117 @@
 package database
 
 import (
-	"github.com/your-username/go-clean-architecture/internal/entity"
+	"fmt"
+	"strings"
+
 	"github.com/your-username/go-clean-architecture/pkg/logger"
 	"gorm.io/gorm"
 )
 
-// Seeder handles database seeding
-type Seeder struct {
-	db *gorm.DB
+// Seeder seeds the data for one entity. Seeders must be idempotent, so that
+// running them again only creates rows that are missing.
+type Seeder interface {
+	// Name identifies the seeder for the -only flag
+	Name() string
+	// Tables lists the tables the seeder fills, truncated by -fresh
+	Tables() []string
+	// Seed creates missing rows and reports what it did
+	Seed(db *gorm.DB) (Result, error)
 }
 
-// NewSeeder creates a new seeder instance
-func NewSeeder(db *gorm.DB) *Seeder {
-	return &Seeder{db: db}
+// Result counts the rows a seeder created and skipped
+type Result struct {
+	Created int
+	Skipped int
 }
 
-// Seed runs all seeders
-func (s *Seeder) Seed() error {
-	logger.Info("Running database seeders...")
+// Seeders returns all seeders in the order they run. Register new entity
+// seeders here, after the seeders they depend on.
+func Seeders() []Seeder {
+	return []Seeder{
+		UserSeeder{},
+	}
+}
 
-	if err := s.seedUsers(); err != nil {
+// Runner runs a set of seeders
+type Runner struct {
+	db      *gorm.DB
+	seeders []Seeder
+}
+
+// NewRunner creates a runner for the given seeders, defaulting to Seeders()
+func NewRunner(db *gorm.DB, seeders ...Seeder) *Runner {
+	if len(seeders) == 0 {
+		seeders = Seeders()
+	}
+	return &Runner{db: db, seeders: seeders}
+}
+
+// Seed runs the seeders named in only, or all of them if only is empty, in
+// registration order
+func (r *Runner) Seed(only ...string) error {
+	seeders, err := r.selected(only)
+	if err != nil {
 		return err
 	}
 
+	logger.Info("Running database seeders...")
+
+	for _, seeder := range seeders {
+		result, err := seeder.Seed(r.db)
+		if err != nil {
+			return fmt.Errorf("seeder %s: %w", seeder.Name(), err)
+		}
+		logger.Infof("Seeded %s: %d created, %d skipped", seeder.Name(), result.Created, result.Skipped)
+	}
+
 	logger.Info("Database seeding completed!")
 	return nil
 }
 
-// seedUsers seeds the users table
-func (s *Seeder) seedUsers() error {
-	users := []entity.User{
-		{
-			Name:     "Admin User",
-			Email:    "admin@example.com",
-			Password: "$2a$10$N9qo8uLOickgx2ZMRZoMye.fVKCBd/h.GqwYY.0mvVxQhVGDtJa7C", // password: password123
-			Role:     "admin",
-			IsActive: true,
-		},
-		{
-			Name:     "Regular User",
-			Email:    "user@example.com",
-			Password: "$2a$10$N9qo8uLOickgx2ZMRZoMye.fVKCBd/h.GqwYY.0mvVxQhVGDtJa7C", // password: password123
-			Role:     "user",
-			IsActive: true,
-		},
+// Truncate empties the tables of the seeders named in only, or of all of
+// them if only is empty. Intended for local development only.
+func (r *Runner) Truncate(only ...string) error {
+	seeders, err := r.selected(only)
+	if err != nil {
+		return err
 	}
 
-	for _, user := range users {
-		var existing entity.User
-		if err := s.db.Where("email = ?", user.Email).First(&existing).Error; err == nil {
-			logger.Infof("User %s already exists, skipping...", user.Email)
-			continue
+	// Truncate in reverse so dependent tables go first
+	for i := len(seeders) - 1; i >= 0; i-- {
+		for _, table := range seeders[i].Tables() {
+			if err := r.db.Exec(fmt.Sprintf("TRUNCATE TABLE %s RESTART IDENTITY CASCADE", table)).Error; err != nil {
+				return fmt.Errorf("truncate %s: %w", table, err)
+			}
+			logger.Infof("Truncated table %s", table)
 		}
+	}
 
-		if err := s.db.Create(&user).Error; err != nil {
-			return err
+	return nil
+}
+
+// selected returns the seeders named in only, keeping registration order
+func (r *Runner) selected(only []string) ([]Seeder, error) {
+	if len(only) == 0 {
+		return r.seeders, nil
+	}
+
+	wanted := make(map[string]bool, len(only))
+	for _, name := range only {
+		wanted[strings.TrimSpace(name)] = true
+	}
+
+	seeders := make([]Seeder, 0, len(only))
+	for _, seeder := range r.seeders {
+		if wanted[seeder.Name()] {
+			seeders = append(seeders, seeder)
+			delete(wanted, seeder.Name())
 		}
-		logger.Infof("Created user: %s", user.Email)
 	}
 
-	return nil
+	for name := range wanted {
+		return nil, fmt.Errorf("unknown seeder: %s", name)
+	}
+
+	return seeders, nil
 }
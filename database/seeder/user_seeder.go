@@ -0,0 +1,57 @@
+package database
+
+import (
+	"github.com/your-username/go-clean-architecture/internal/entity"
+	"github.com/your-username/go-clean-architecture/pkg/constants"
+	"gorm.io/gorm"
+)
+
+// UserSeeder seeds the default admin and regular users
+type UserSeeder struct{}
+
+// Name implements Seeder
+func (UserSeeder) Name() string {
+	return "users"
+}
+
+// Tables implements Seeder
+func (UserSeeder) Tables() []string {
+	return []string{"users"}
+}
+
+// Seed implements Seeder. Users are matched by email, including soft-deleted
+// ones, so existing accounts are never duplicated or modified.
+func (UserSeeder) Seed(db *gorm.DB) (Result, error) {
+	users := []entity.User{
+		{
+			Name:     "Admin User",
+			Email:    "admin@example.com",
+			Password: "$2a$10$N9qo8uLOickgx2ZMRZoMye.fVKCBd/h.GqwYY.0mvVxQhVGDtJa7C", // password: password123
+			Role:     "admin",
+			Status:   constants.UserStatusActive,
+		},
+		{
+			Name:     "Regular User",
+			Email:    "user@example.com",
+			Password: "$2a$10$N9qo8uLOickgx2ZMRZoMye.fVKCBd/h.GqwYY.0mvVxQhVGDtJa7C", // password: password123
+			Role:     "user",
+			Status:   constants.UserStatusActive,
+		},
+	}
+
+	var result Result
+	for _, user := range users {
+		var existing entity.User
+		tx := db.Unscoped().Where(entity.User{Email: user.Email}).Attrs(user).FirstOrCreate(&existing)
+		if tx.Error != nil {
+			return result, tx.Error
+		}
+		if tx.RowsAffected > 0 {
+			result.Created++
+		} else {
+			result.Skipped++
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,69 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/your-username/go-clean-architecture/config"
+	"github.com/your-username/go-clean-architecture/internal/entity"
+	"github.com/your-username/go-clean-architecture/internal/repository"
+	"github.com/your-username/go-clean-architecture/pkg/database"
+	"github.com/your-username/go-clean-architecture/pkg/database/dbtest"
+)
+
+func TestUserSeederDeleteModes(t *testing.T) {
+	tests := []struct {
+		name       string
+		hardDelete bool
+		// want is the result of seeding again after the admin was deleted
+		want Result
+	}{
+		// The soft-deleted admin is matched and left deleted
+		{name: "soft", hardDelete: false, want: Result{Skipped: 2}},
+		// The admin's row is gone, so it is seeded again
+		{name: "hard", hardDelete: true, want: Result{Created: 1, Skipped: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := dbtest.New(t, &entity.User{})
+			repo := repository.NewUserRepository(db, database.NewTransactor(db, config.RetryConfig{}), time.Second, tt.hardDelete)
+			ctx := context.Background()
+
+			if result, err := (UserSeeder{}).Seed(db); err != nil || result != (Result{Created: 2}) {
+				t.Fatalf("first Seed() = %+v, %v; want 2 created", result, err)
+			}
+			if result, err := (UserSeeder{}).Seed(db); err != nil || result != (Result{Skipped: 2}) {
+				t.Fatalf("second Seed() = %+v, %v; want 2 skipped", result, err)
+			}
+
+			admin, err := repo.FindByEmail(ctx, "admin@example.com")
+			if err != nil {
+				t.Fatalf("FindByEmail() error = %v", err)
+			}
+			if err := repo.Delete(ctx, admin.ID); err != nil {
+				t.Fatalf("Delete() error = %v", err)
+			}
+
+			result, err := (UserSeeder{}).Seed(db)
+			if err != nil {
+				t.Fatalf("Seed() after deleting the admin error = %v", err)
+			}
+			if result != tt.want {
+				t.Errorf("Seed() after deleting the admin = %+v, want %+v", result, tt.want)
+			}
+
+			// Never a duplicate account, deleted or not
+			var rows int64
+			db.Unscoped().Model(&entity.User{}).Where("email = ?", "admin@example.com").Count(&rows)
+			if rows != 1 {
+				t.Errorf("%d admin rows after seeding again, want 1", rows)
+			}
+			_, err = repo.FindByEmail(ctx, "admin@example.com")
+			if active := err == nil; active != tt.hardDelete {
+				t.Errorf("admin active = %v after seeding again in %s mode", active, tt.name)
+			}
+		})
+	}
+}
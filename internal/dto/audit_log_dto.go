@@ -0,0 +1,21 @@
+package dto
+
+import "encoding/json"
+
+// AuditLogFilterRequest represents the audit log list filters
+type AuditLogFilterRequest struct {
+	ActorID *uint  `form:"actor_id" binding:"omitempty,min=1" example:"1"`
+	Action  string `form:"action" binding:"omitempty,max=100" example:"user.login"`
+}
+
+// AuditLogResponse represents the audit log response
+type AuditLogResponse struct {
+	ID         uint            `json:"id" example:"1"`
+	ActorID    *uint           `json:"actor_id" example:"1"`
+	Action     string          `json:"action" example:"user.login"`
+	TargetType string          `json:"target_type,omitempty" example:"user"`
+	TargetID   *uint           `json:"target_id,omitempty" example:"1"`
+	Metadata   json.RawMessage `json:"metadata,omitempty" swaggertype:"object"`
+	IP         string          `json:"ip,omitempty" example:"127.0.0.1"`
+	CreatedAt  Timestamp       `json:"created_at" swaggertype:"string" format:"date-time" example:"2024-01-01T00:00:00Z"`
+}
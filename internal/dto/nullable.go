@@ -0,0 +1,33 @@
+package dto
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Nullable is a JSON field that tells an omitted field apart from an explicit
+// null. Set is true when the field was present, Null when it was null.
+type Nullable[T any] struct {
+	Set   bool
+	Null  bool
+	Value T
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It is only called for fields
+// present in the document, which is what sets Set.
+func (n *Nullable[T]) UnmarshalJSON(data []byte) error {
+	n.Set = true
+	if bytes.Equal(data, []byte("null")) {
+		n.Null = true
+		return nil
+	}
+	return json.Unmarshal(data, &n.Value)
+}
+
+// Ptr returns the value, or nil when the field was omitted or null
+func (n Nullable[T]) Ptr() *T {
+	if !n.Set || n.Null {
+		return nil
+	}
+	return &n.Value
+}
@@ -0,0 +1,96 @@
+package dto
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/your-username/go-clean-architecture/config"
+)
+
+// unixMillisThreshold tells Unix seconds from Unix milliseconds when reading
+// a number: as milliseconds it is early 1973, as seconds the year 5138
+const unixMillisThreshold = 1e11
+
+var (
+	timestampFormat   = config.TimestampFormatRFC3339
+	timestampFormatMu sync.RWMutex
+)
+
+// SetTimestampFormat sets how every Timestamp is written: config.TimestampFormatRFC3339,
+// config.TimestampFormatUnix or config.TimestampFormatUnixMillis. Unknown
+// formats fall back to RFC 3339.
+func SetTimestampFormat(format string) {
+	switch format {
+	case config.TimestampFormatUnix, config.TimestampFormatUnixMillis:
+	default:
+		format = config.TimestampFormatRFC3339
+	}
+
+	timestampFormatMu.Lock()
+	defer timestampFormatMu.Unlock()
+	timestampFormat = format
+}
+
+// TimestampFormat returns the format every Timestamp is written in
+func TimestampFormat() string {
+	timestampFormatMu.RLock()
+	defer timestampFormatMu.RUnlock()
+	return timestampFormat
+}
+
+// Timestamp is a time in a response, written in the configured format so
+// every endpoint agrees on it. RFC 3339 times are always in UTC.
+type Timestamp struct {
+	time.Time
+}
+
+// NewTimestamp wraps t
+func NewTimestamp(t time.Time) Timestamp {
+	return Timestamp{Time: t}
+}
+
+// NewTimestampPtr wraps t, returning nil for the zero time
+func NewTimestampPtr(t time.Time) *Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return &Timestamp{Time: t}
+}
+
+// MarshalJSON implements json.Marshaler
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	switch TimestampFormat() {
+	case config.TimestampFormatUnix:
+		return strconv.AppendInt(nil, t.Unix(), 10), nil
+	case config.TimestampFormatUnixMillis:
+		return strconv.AppendInt(nil, t.UnixMilli(), 10), nil
+	default:
+		return json.Marshal(t.UTC().Format(time.RFC3339))
+	}
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It reads any of the formats
+// whatever the configured one, so values cached before the format changed
+// still load.
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		return t.Time.UnmarshalJSON(data)
+	}
+
+	n, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil {
+		return err
+	}
+	if n >= unixMillisThreshold {
+		t.Time = time.UnixMilli(n).UTC()
+	} else {
+		t.Time = time.Unix(n, 0).UTC()
+	}
+	return nil
+}
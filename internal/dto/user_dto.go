@@ -1,12 +1,10 @@
 package dto
 
-import "time"
-
 // RegisterRequest represents the register request body
 type RegisterRequest struct {
 	Name     string `json:"name" binding:"required,min=2,max=100" example:"John Doe"`
-	Email    string `json:"email" binding:"required,email" example:"john@example.com"`
-	Password string `json:"password" binding:"required,min=6" example:"password123"`
+	Email    string `json:"email" binding:"required,email,unique_email" example:"john@example.com"`
+	Password string `json:"password" binding:"required,strong_password" example:"Passw0rd!"`
 }
 
 // LoginRequest represents the login request body
@@ -15,26 +13,156 @@ type LoginRequest struct {
 	Password string `json:"password" binding:"required" example:"password123"`
 }
 
-// UpdateUserRequest represents the update user request body
+// UpdateUserRequest represents the update user request body. PUT replaces
+// the profile, so name and email are required; an omitted password is left
+// unchanged. When Version is set the update is rejected with 409 unless it
+// matches the current version.
 type UpdateUserRequest struct {
-	Name     string `json:"name" binding:"omitempty,min=2,max=100" example:"John Doe Updated"`
-	Email    string `json:"email" binding:"omitempty,email" example:"john.updated@example.com"`
-	Password string `json:"password" binding:"omitempty,min=6" example:"newpassword123"`
+	Name     *string `json:"name" binding:"required,min=2,max=100" example:"John Doe Updated"`
+	Email    *string `json:"email" binding:"required,email" example:"john.updated@example.com"`
+	Password *string `json:"password" binding:"omitnil,strong_password" example:"NewPassw0rd!"`
+	Version  *int    `json:"version" binding:"omitempty,min=1" example:"3"`
+}
+
+// PatchUserRequest represents the patch user request body. Omitted fields are
+// left unchanged and at least one field must be present. Only avatar_url may
+// be null, which removes the avatar; upload a file to change it.
+type PatchUserRequest struct {
+	Name      Nullable[string] `json:"name" binding:"omitnil,min=2,max=100" swaggertype:"string" example:"John Doe Updated"`
+	Email     Nullable[string] `json:"email" binding:"omitnil,email" swaggertype:"string" example:"john.updated@example.com"`
+	Password  Nullable[string] `json:"password" binding:"omitnil,strong_password" swaggertype:"string" example:"NewPassw0rd!"`
+	AvatarURL Nullable[string] `json:"avatar_url" swaggertype:"string"`
+	Version   *int             `json:"version" binding:"omitempty,min=1" example:"3"`
+}
+
+// IsEmpty reports whether the request changes no field
+func (r *PatchUserRequest) IsEmpty() bool {
+	return !r.Name.Set && !r.Email.Set && !r.Password.Set && !r.AvatarURL.Set
+}
+
+// PurgeUserRequest represents the purge user request body. ConfirmEmail must
+// match the email of the user being purged.
+type PurgeUserRequest struct {
+	ConfirmEmail string `json:"confirm_email" binding:"required,email" example:"john@example.com"`
+}
+
+// BatchGetUsersRequest represents the batch get users request body
+type BatchGetUsersRequest struct {
+	IDs []uint `json:"ids" binding:"required,min=1,max=100,dive,min=1" example:"1,2,3"`
+}
+
+// BatchGetUsersResponse holds the users found, in the requested order, and
+// the requested IDs that do not exist
+type BatchGetUsersResponse struct {
+	Users    []UserResponse `json:"users"`
+	NotFound []uint         `json:"not_found"`
+}
+
+// BatchDeleteUsersRequest represents the batch delete users request body
+type BatchDeleteUsersRequest struct {
+	IDs []uint `json:"ids" binding:"required,min=1,max=100,dive,min=1" example:"1,2,3"`
+}
+
+// Batch delete outcomes
+const (
+	BatchDeleteDeleted  = "deleted"
+	BatchDeleteNotFound = "not_found"
+)
+
+// BatchDeleteResult is the outcome of deleting one user of a batch
+type BatchDeleteResult struct {
+	ID     uint   `json:"id" example:"1"`
+	Status string `json:"status" enums:"deleted,not_found" example:"deleted"`
+}
+
+// BatchDeleteUsersResponse holds the outcome for each requested ID, in the
+// requested order, and how many users were deleted
+type BatchDeleteUsersResponse struct {
+	Results []BatchDeleteResult `json:"results"`
+	Deleted int                 `json:"deleted" example:"2"`
+}
+
+// UserFilterRequest represents the user list filters
+type UserFilterRequest struct {
+	Role     string `form:"role" binding:"omitempty,oneof=admin user" example:"user"`
+	Status   string `form:"status" binding:"omitempty,oneof=pending active suspended banned" example:"active"`
+	IsActive *bool  `form:"is_active" example:"true"`
+	Search   string `form:"search" binding:"omitempty,max=100" example:"john"`
+}
+
+// UserSearchRequest represents the admin user search query
+type UserSearchRequest struct {
+	Q string `form:"q" binding:"required,max=100" example:"john"`
+	// IncludeDeleted also matches soft-deleted users
+	IncludeDeleted bool `form:"include_deleted" example:"false"`
+}
+
+// UserExportRequest represents the admin user export query
+type UserExportRequest struct {
+	UserFilterRequest
+	// IncludeDeleted also exports soft-deleted users
+	IncludeDeleted bool `form:"include_deleted" example:"false"`
 }
 
 // UserResponse represents the user response
 type UserResponse struct {
-	ID        uint      `json:"id" example:"1"`
-	Name      string    `json:"name" example:"John Doe"`
-	Email     string    `json:"email" example:"john@example.com"`
-	Role      string    `json:"role" example:"user"`
-	IsActive  bool      `json:"is_active" example:"true"`
-	CreatedAt time.Time `json:"created_at" example:"2024-01-01T00:00:00Z"`
-	UpdatedAt time.Time `json:"updated_at" example:"2024-01-01T00:00:00Z"`
+	ID     uint   `json:"id" example:"1"`
+	Name   string `json:"name" example:"John Doe"`
+	Email  string `json:"email" example:"john@example.com"`
+	Role   string `json:"role" example:"user"`
+	Status string `json:"status" example:"active"`
+	// IsActive is derived from Status, kept for clients that predate it
+	IsActive  bool       `json:"is_active" example:"true"`
+	AvatarURL string     `json:"avatar_url,omitempty" example:"/uploads/avatars/1.png?v=1704067200"`
+	Version   int        `json:"version" example:"3"`
+	CreatedAt Timestamp  `json:"created_at" swaggertype:"string" format:"date-time" example:"2024-01-01T00:00:00Z"`
+	UpdatedAt Timestamp  `json:"updated_at" swaggertype:"string" format:"date-time" example:"2024-01-01T00:00:00Z"`
+	DeletedAt *Timestamp `json:"deleted_at,omitempty" swaggertype:"string" format:"date-time" example:"2024-01-02T00:00:00Z"`
+}
+
+// ChangeRoleRequest represents an admin role change
+type ChangeRoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=admin user" example:"admin"`
+}
+
+// ChangeStatusRequest represents an admin status transition
+type ChangeStatusRequest struct {
+	Status string `json:"status" binding:"required,oneof=pending active suspended banned" example:"suspended"`
+}
+
+// DeactivateUserRequest represents the deactivation options
+type DeactivateUserRequest struct {
+	// RevokeTokens ends the user's sessions, it defaults to true
+	RevokeTokens *bool `form:"revoke_tokens" example:"true"`
+}
+
+// IntrospectRequest represents a token introspection request
+type IntrospectRequest struct {
+	Token string `json:"token" binding:"required" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+}
+
+// IntrospectResponse describes a token. Claims are only set for active tokens.
+type IntrospectResponse struct {
+	Active    bool       `json:"active" example:"true"`
+	UserID    uint       `json:"user_id,omitempty" example:"1"`
+	Email     string     `json:"email,omitempty" example:"john@example.com"`
+	Role      string     `json:"role,omitempty" example:"user"`
+	ExpiresAt *Timestamp `json:"expires_at,omitempty" swaggertype:"string" format:"date-time" example:"2024-01-02T00:00:00Z"`
+	IssuedAt  *Timestamp `json:"issued_at,omitempty" swaggertype:"string" format:"date-time" example:"2024-01-01T00:00:00Z"`
+}
+
+// PermissionsResponse describes what the current user may do
+type PermissionsResponse struct {
+	UserID      uint     `json:"user_id" example:"1"`
+	Roles       []string `json:"roles" example:"user"`
+	Permissions []string `json:"permissions" example:"users:read"`
+	Features    []string `json:"features" example:"new_dashboard"`
 }
 
 // LoginResponse represents the login response
 type LoginResponse struct {
-	Token string       `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
-	User  UserResponse `json:"user"`
+	Token     string       `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	ExpiresAt Timestamp    `json:"expires_at" swaggertype:"string" format:"date-time" example:"2024-01-02T00:00:00Z"`
+	ExpiresIn int          `json:"expires_in" example:"86400"`
+	User      UserResponse `json:"user"`
 }
@@ -0,0 +1,36 @@
+package dto
+
+import (
+	"reflect"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// RegisterValidation teaches v the request types that need more than tags.
+// It is passed to validator.RegisterGinValidator at startup.
+func RegisterValidation(v *validator.Validate) {
+	// Tags on a nullable field apply to its value, as if it were a pointer
+	// that is nil when the field is omitted or null
+	v.RegisterCustomTypeFunc(func(field reflect.Value) interface{} {
+		return field.Interface().(Nullable[string]).Ptr()
+	}, Nullable[string]{})
+
+	v.RegisterStructValidation(validatePatchUserRequest, PatchUserRequest{})
+}
+
+// validatePatchUserRequest rejects null for the fields that cannot be cleared
+func validatePatchUserRequest(sl validator.StructLevel) {
+	req := sl.Current().Interface().(PatchUserRequest)
+	for name, field := range map[string]Nullable[string]{
+		"name":     req.Name,
+		"email":    req.Email,
+		"password": req.Password,
+	} {
+		if field.Null {
+			sl.ReportError(field, name, name, "required", "")
+		}
+	}
+	if req.AvatarURL.Set && !req.AvatarURL.Null {
+		sl.ReportError(req.AvatarURL, "avatar_url", "AvatarURL", "null_only", "")
+	}
+}
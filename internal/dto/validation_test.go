@@ -0,0 +1,57 @@
+package dto
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+)
+
+func TestRegisterValidationPatchUserRequest(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		wantField string
+		wantTag   string
+	}{
+		{name: "omitted fields", body: `{}`},
+		{name: "valid name", body: `{"name":"Jane Doe"}`},
+		{name: "short name", body: `{"name":"J"}`, wantField: "Name", wantTag: "min"},
+		{name: "null name", body: `{"name":null}`, wantField: "name", wantTag: "required"},
+		{name: "null email", body: `{"email":null}`, wantField: "email", wantTag: "required"},
+		{name: "avatar cleared", body: `{"avatar_url":null}`},
+		{name: "avatar set", body: `{"avatar_url":"https://example.com/a.png"}`, wantField: "avatar_url", wantTag: "null_only"},
+	}
+
+	v := validator.New()
+	v.SetTagName("binding")
+	// strong_password belongs to pkg/validator, accept any password here
+	_ = v.RegisterValidation("strong_password", func(validator.FieldLevel) bool { return true })
+	RegisterValidation(v)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var req PatchUserRequest
+			if err := json.Unmarshal([]byte(tt.body), &req); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+
+			err := v.Struct(req)
+			if tt.wantTag == "" {
+				if err != nil {
+					t.Errorf("Struct() error = %v, want nil", err)
+				}
+				return
+			}
+
+			var errs validator.ValidationErrors
+			if !errors.As(err, &errs) || len(errs) != 1 {
+				t.Fatalf("Struct() error = %v, want one %s error on %s", err, tt.wantTag, tt.wantField)
+			}
+			if errs[0].Field() != tt.wantField || errs[0].Tag() != tt.wantTag {
+				t.Errorf("Struct() error on %s %s, want %s %s", errs[0].Field(), errs[0].Tag(), tt.wantField, tt.wantTag)
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package entity
+
+import "time"
+
+// AuditLog records a sensitive operation performed by a user
+type AuditLog struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	ActorID    *uint     `json:"actor_id" gorm:"index"`
+	Action     string    `json:"action" gorm:"size:100;not null;index"`
+	TargetType string    `json:"target_type" gorm:"size:100"`
+	TargetID   *uint     `json:"target_id"`
+	Metadata   string    `json:"metadata" gorm:"type:jsonb;default:'{}'"`
+	IP         string    `json:"ip" gorm:"size:45"`
+	CreatedAt  time.Time `json:"created_at" gorm:"index"`
+}
+
+// TableName returns the table name for the AuditLog model
+func (AuditLog) TableName() string {
+	return "audit_logs"
+}
@@ -3,22 +3,35 @@ package entity
 import (
 	"time"
 
+	"github.com/your-username/go-clean-architecture/pkg/constants"
 	"gorm.io/gorm"
 )
 
 // User represents the user entity
 type User struct {
-	ID        uint           `json:"id" gorm:"primaryKey"`
-	Name      string         `json:"name" gorm:"size:255;not null"`
-	Email     string         `json:"email" gorm:"size:255;uniqueIndex;not null"`
-	Password  string         `json:"-" gorm:"size:255;not null"`
-	Role      string         `json:"role" gorm:"size:50;default:'user'"`
-	IsActive  bool           `json:"is_active" gorm:"default:true"`
+	ID   uint   `json:"id" gorm:"primaryKey"`
+	Name string `json:"name" gorm:"size:255;not null"`
+	// Email is only unique among users that are not deleted
+	Email    string `json:"email" gorm:"size:255;not null;uniqueIndex:idx_users_email_active,where:deleted_at IS NULL"`
+	Password string `json:"-" gorm:"size:255;not null"`
+	Role     string `json:"role" gorm:"size:50;default:'user'"`
+	// Status is one of the constants.UserStatus values
+	Status    string `json:"status" gorm:"size:20;not null;default:'active';index"`
+	AvatarURL string `json:"avatar_url" gorm:"size:500"`
+	// TokenVersion is embedded in issued tokens, bumping it revokes them all
+	TokenVersion uint `json:"-" gorm:"not null;default:0"`
+	// Version is incremented by every update, a stale update is rejected
+	Version   int            `json:"version" gorm:"not null;default:1"`
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
 }
 
+// IsActive reports whether the user's status allows logging in
+func (u *User) IsActive() bool {
+	return u.Status == constants.UserStatusActive
+}
+
 // TableName returns the table name for the User model
 func (User) TableName() string {
 	return "users"
@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/your-username/go-clean-architecture/internal/dto"
+	"github.com/your-username/go-clean-architecture/internal/usecase"
+	"github.com/your-username/go-clean-architecture/pkg/i18n"
+	"github.com/your-username/go-clean-architecture/pkg/pagination"
+	"github.com/your-username/go-clean-architecture/pkg/response"
+	"github.com/your-username/go-clean-architecture/pkg/validator"
+)
+
+// AuditLogHandler handles HTTP requests for audit logs
+type AuditLogHandler struct {
+	auditUseCase usecase.AuditUseCase
+}
+
+// NewAuditLogHandler creates a new audit log handler
+func NewAuditLogHandler(auditUseCase usecase.AuditUseCase) *AuditLogHandler {
+	return &AuditLogHandler{
+		auditUseCase: auditUseCase,
+	}
+}
+
+// RegisterRoutes registers the audit log routes on rg
+func (h *AuditLogHandler) RegisterRoutes(rg *gin.RouterGroup, mw RouteMiddleware) {
+	admin := rg.Group("/admin", mw.Timeout, mw.Authenticated, mw.AdminOnly)
+	{
+		admin.GET("/audit-logs", h.GetAuditLogs)
+	}
+}
+
+// GetAuditLogs godoc
+// @Summary Get audit logs
+// @Description Get audit log entries, newest first, with pagination (admin only)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Limit per page" default(10)
+// @Param count query string false "Total: true for an exact count, estimate for a fast estimate, false to omit it" Enums(true, estimate, false) default(true)
+// @Param actor_id query int false "Filter by actor ID"
+// @Param action query string false "Filter by action" example(user.login)
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=[]dto.AuditLogResponse}
+// @Failure 422 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /api/v1/admin/audit-logs [get]
+func (h *AuditLogHandler) GetAuditLogs(c *gin.Context) {
+	var filter dto.AuditLogFilterRequest
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		errors := validator.FormatValidationErrors(err, i18n.FromContext(c))
+		response.ValidationError(c, errors)
+		return
+	}
+
+	page, limit := pagination.Bind(c)
+	mode := pagination.BindTotal(c)
+
+	logs, total, err := h.auditUseCase.GetAll(c.Request.Context(), &filter, page, limit, mode)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response.PaginateWithTotal(c, i18n.MsgAuditLogsRetrieved, logs, page, limit, total, mode)
+}
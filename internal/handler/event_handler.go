@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-username/go-clean-architecture/pkg/i18n"
+	"github.com/your-username/go-clean-architecture/pkg/logger"
+	"github.com/your-username/go-clean-architecture/pkg/realtime"
+	"github.com/your-username/go-clean-architecture/pkg/response"
+)
+
+// EventHandler handles server-sent event streams
+type EventHandler struct {
+	broker    realtime.Broker
+	heartbeat time.Duration
+	// shutdownCtx is cancelled when the server starts shutting down
+	shutdownCtx context.Context
+}
+
+// NewEventHandler creates a new event handler. Open streams end when
+// shutdownCtx is cancelled.
+func NewEventHandler(broker realtime.Broker, heartbeat time.Duration, shutdownCtx context.Context) *EventHandler {
+	return &EventHandler{
+		broker:      broker,
+		heartbeat:   heartbeat,
+		shutdownCtx: shutdownCtx,
+	}
+}
+
+// RegisterRoutes registers the event stream route on rg. Server-sent events
+// stay open, so the stream runs without the request timeout.
+func (h *EventHandler) RegisterRoutes(rg *gin.RouterGroup, mw RouteMiddleware) {
+	rg.GET("/events", mw.Authenticated, h.Stream)
+}
+
+// Stream godoc
+// @Summary Stream events
+// @Description Stream events for the current user as server-sent events. Idle streams receive a heartbeat comment. When the server shuts down, a server.shutdown event ends the stream and the client should reconnect.
+// @Tags Users
+// @Produce text/event-stream
+// @Security BearerAuth
+// @Success 200 {object} realtime.Event
+// @Failure 401 {object} response.Response
+// @Router /api/v1/events [get]
+func (h *EventHandler) Stream(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		response.Unauthorized(c, i18n.MsgUserNotAuthenticated)
+		return
+	}
+
+	events, unsubscribe := h.broker.Subscribe(userID.(uint))
+	defer unsubscribe()
+
+	// Streams stay open far longer than the server's write timeout
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		logger.Warnf("Failed to clear write deadline for event stream: %v", err)
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	// Stop proxies such as nginx from buffering the stream
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	ctx := c.Request.Context()
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-h.shutdownCtx.Done():
+			c.SSEvent(realtime.EventServerShutdown, realtime.Event{Type: realtime.EventServerShutdown})
+			return false
+		case event, ok := <-events:
+			if !ok {
+				return false
+			}
+			c.SSEvent(event.Type, event)
+			return true
+		case <-heartbeat.C:
+			_, err := io.WriteString(w, ": heartbeat\n\n")
+			return err == nil
+		}
+	})
+}
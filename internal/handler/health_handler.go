@@ -1,16 +1,28 @@
 package handler
 
 import (
+	"database/sql"
+
 	"github.com/gin-gonic/gin"
+	"github.com/your-username/go-clean-architecture/pkg/i18n"
+	"github.com/your-username/go-clean-architecture/pkg/logger"
 	"github.com/your-username/go-clean-architecture/pkg/response"
 )
 
+// PoolStatsSource reports database connection pool statistics
+type PoolStatsSource interface {
+	PoolStats() (sql.DBStats, error)
+}
+
 // HealthHandler handles health check requests
-type HealthHandler struct{}
+type HealthHandler struct {
+	pool PoolStatsSource
+}
 
-// NewHealthHandler creates a new health handler
-func NewHealthHandler() *HealthHandler {
-	return &HealthHandler{}
+// NewHealthHandler creates a new health handler. pool may be nil, in which
+// case readiness responses carry no pool statistics.
+func NewHealthHandler(pool PoolStatsSource) *HealthHandler {
+	return &HealthHandler{pool: pool}
 }
 
 // Health godoc
@@ -22,21 +34,38 @@ func NewHealthHandler() *HealthHandler {
 // @Success 200 {object} response.Response
 // @Router /health [get]
 func (h *HealthHandler) Health(c *gin.Context) {
-	response.Success(c, "Service is running", gin.H{
+	response.Success(c, i18n.MsgServiceRunning, gin.H{
 		"status": "healthy",
 	})
 }
 
 // Ready godoc
 // @Summary Readiness check
-// @Description Check if the service is ready to receive traffic
+// @Description Check if the service is ready to receive traffic. The response includes the database connection pool statistics.
 // @Tags Health
 // @Accept json
 // @Produce json
 // @Success 200 {object} response.Response
 // @Router /ready [get]
 func (h *HealthHandler) Ready(c *gin.Context) {
-	response.Success(c, "Service is ready", gin.H{
+	data := gin.H{
 		"status": "ready",
-	})
+	}
+
+	if h.pool != nil {
+		if stats, err := h.pool.PoolStats(); err != nil {
+			logger.Warnf("Failed to read database pool stats: %v", err)
+		} else {
+			data["database_pool"] = gin.H{
+				"max_open_connections": stats.MaxOpenConnections,
+				"open_connections":     stats.OpenConnections,
+				"in_use":               stats.InUse,
+				"idle":                 stats.Idle,
+				"wait_count":           stats.WaitCount,
+				"wait_duration_ms":     stats.WaitDuration.Milliseconds(),
+			}
+		}
+	}
+
+	response.Success(c, i18n.MsgServiceReady, data)
 }
@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/your-username/go-clean-architecture/config"
+	"github.com/your-username/go-clean-architecture/internal/dto"
+	"github.com/your-username/go-clean-architecture/pkg/apperrors"
+	"github.com/your-username/go-clean-architecture/pkg/i18n"
+	"github.com/your-username/go-clean-architecture/pkg/response"
+	"github.com/your-username/go-clean-architecture/pkg/validator"
+)
+
+// bindJSON binds the JSON body into obj and validates it against its binding
+// tags. A failure is answered as described by respondBindError, and bindJSON
+// reports whether binding succeeded.
+func bindJSON(c *gin.Context, obj any) bool {
+	if err := c.ShouldBindJSON(obj); err != nil {
+		respondBindError(c, err)
+		return false
+	}
+	return true
+}
+
+// strictBinding is whether BindStrict rejects unknown fields
+var strictBinding atomic.Bool
+
+// SetStrictBinding sets which JSON bodies may not have fields their request
+// type lacks: none for config.StrictBindingOff, those bound with BindStrict
+// for config.StrictBindingRoutes, or every body for config.StrictBindingAll
+func SetStrictBinding(mode string) {
+	strictBinding.Store(mode == config.StrictBindingRoutes || mode == config.StrictBindingAll)
+	binding.EnableDecoderDisallowUnknownFields = mode == config.StrictBindingAll
+}
+
+// BindStrict binds like bindJSON, except that when strict binding is on for
+// its routes, unknown fields are answered with a 400 naming the field. It is
+// used where a typo'd field would otherwise go unnoticed, such as on
+// register, login and user updates.
+func BindStrict(c *gin.Context, obj any) bool {
+	if !strictBinding.Load() {
+		return bindJSON(c, obj)
+	}
+
+	if c.Request.Body == nil {
+		respondBindError(c, io.EOF)
+		return false
+	}
+	decoder := json.NewDecoder(c.Request.Body)
+	decoder.DisallowUnknownFields()
+	if binding.EnableDecoderUseNumber {
+		decoder.UseNumber()
+	}
+	if err := decoder.Decode(obj); err != nil {
+		respondBindError(c, err)
+		return false
+	}
+	if err := binding.Validator.ValidateStruct(obj); err != nil {
+		respondBindError(c, err)
+		return false
+	}
+	return true
+}
+
+// respondBindError writes the response for a failed request bind. Bodies cut
+// off by the body limit are reported as 413, bodies that are not valid JSON or
+// do not fit the request type as 400, anything else as validation errors.
+func respondBindError(c *gin.Context, err error) {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		_ = c.Error(apperrors.ErrPayloadTooLarge)
+		return
+	}
+
+	if details, ok := malformedBodyDetails(err); ok {
+		malformed := apperrors.ErrMalformedBody
+		response.ErrorWithCode(c, malformed.Code, malformed.Slug, i18n.Key(malformed.Message), details)
+		return
+	}
+
+	response.ValidationError(c, validator.FormatValidationErrors(err, i18n.FromContext(c)))
+}
+
+// malformedBodyDetails describes a JSON body that could not be decoded:
+// where it stopped being valid JSON, the field holding a value of the wrong
+// type, or a field the request type does not have
+func malformedBodyDetails(err error) (gin.H, bool) {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.Is(err, io.EOF):
+		return gin.H{"reason": "body is empty"}, true
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return gin.H{"reason": "body ends unexpectedly"}, true
+	case errors.As(err, &syntaxErr):
+		return gin.H{"reason": syntaxErr.Error(), "offset": syntaxErr.Offset}, true
+	case errors.As(err, &typeErr):
+		details := gin.H{"reason": "value must be " + jsonTypeName(typeErr.Type), "offset": typeErr.Offset}
+		if typeErr.Field != "" {
+			details["field"] = typeErr.Field
+		}
+		return details, true
+	}
+
+	// The decoder reports unknown fields without an error type of their own
+	if field, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+		return gin.H{"reason": "unknown field", "field": strings.Trim(field, `"`)}, true
+	}
+	return nil, false
+}
+
+// jsonTypeName names the JSON type a Go value is decoded from
+func jsonTypeName(typ reflect.Type) string {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	switch typ.Kind() {
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	default:
+		return "an object"
+	}
+}
+
+// bindQuery binds the query parameters into obj and validates them against
+// its binding tags. Invalid parameters are answered with a validation error
+// naming each of them, and bindQuery reports whether binding succeeded.
+func bindQuery(c *gin.Context, obj any) bool {
+	if err := c.ShouldBindQuery(obj); err != nil {
+		response.ValidationError(c, validator.FormatQueryErrors(err, c.Request.URL.Query(), obj, i18n.FromContext(c)))
+		return false
+	}
+	return true
+}
+
+// parseIDParam parses a numeric ID path parameter
+func parseIDParam(c *gin.Context, name string) (uint, error) {
+	id, err := strconv.ParseUint(c.Param(name), 10, 32)
+	if err != nil {
+		return 0, apperrors.NewAppError(http.StatusBadRequest, apperrors.SlugBadRequest, "Invalid user ID", err)
+	}
+	return uint(id), nil
+}
+
+// csvSafe neutralizes values that spreadsheet applications would evaluate as formulas
+func csvSafe(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// csvTime formats an optional time for CSV, leaving it empty when unset
+func csvTime(t *dto.Timestamp) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,22 @@
+package handler
+
+import "github.com/gin-gonic/gin"
+
+// RouteMiddleware holds the shared middleware handlers attach to their routes
+// in RegisterRoutes. The router builds it once and passes it to every API
+// version, so a handler registers the same way under each.
+type RouteMiddleware struct {
+	// Timeout applies the default request timeout
+	Timeout gin.HandlerFunc
+	// Authenticated requires a valid, unrevoked bearer token
+	Authenticated gin.HandlerFunc
+	// AdminOnly requires the admin role, after Authenticated
+	AdminOnly gin.HandlerFunc
+	// FreshAuth requires a recently issued token for sensitive actions,
+	// after Authenticated
+	FreshAuth gin.HandlerFunc
+	// Idempotent replays responses for retried requests with an Idempotency-Key
+	Idempotent gin.HandlerFunc
+	// AvatarBodyLimit raises the body limit for avatar uploads
+	AvatarBodyLimit gin.HandlerFunc
+}
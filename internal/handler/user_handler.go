@@ -1,11 +1,23 @@
 package handler
 
 import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/your-username/go-clean-architecture/internal/dto"
 	"github.com/your-username/go-clean-architecture/internal/usecase"
+	"github.com/your-username/go-clean-architecture/pkg/apperrors"
+	"github.com/your-username/go-clean-architecture/pkg/fieldset"
+	"github.com/your-username/go-clean-architecture/pkg/i18n"
+	"github.com/your-username/go-clean-architecture/pkg/logger"
+	"github.com/your-username/go-clean-architecture/pkg/pagination"
 	"github.com/your-username/go-clean-architecture/pkg/response"
 	"github.com/your-username/go-clean-architecture/pkg/validator"
 )
@@ -20,6 +32,53 @@ func NewUserHandler(userUseCase usecase.UserUseCase) *UserHandler {
 	return &UserHandler{userUseCase: userUseCase}
 }
 
+// RegisterRoutes registers the auth, user and admin user routes on rg
+func (h *UserHandler) RegisterRoutes(rg *gin.RouterGroup, mw RouteMiddleware) {
+	// Auth routes (public)
+	auth := rg.Group("/auth", mw.Timeout)
+	{
+		auth.POST("/register", mw.Idempotent, h.Register)
+		auth.POST("/login", h.Login)
+		auth.POST("/introspect", h.Introspect)
+	}
+
+	// User routes (protected)
+	users := rg.Group("/users", mw.Timeout, mw.Authenticated)
+	{
+		users.GET("/me", h.GetCurrentUser)
+		users.GET("/me/permissions", h.GetCurrentUserPermissions)
+		users.POST("/me/logout-all", h.LogoutAll)
+		users.POST("/me/avatar", mw.AvatarBodyLimit, h.UploadAvatar)
+		users.GET("", h.GetUsers)
+		users.POST("/batch", h.GetUsersBatch)
+		users.GET("/:id", h.GetUser)
+		// Updates can change the email and password
+		users.PUT("/:id", mw.FreshAuth, h.UpdateUser)
+		users.PATCH("/:id", mw.FreshAuth, h.PatchUser)
+		users.DELETE("/:id", mw.FreshAuth, h.DeleteUser)
+	}
+
+	// Admin routes (protected with role check)
+	admin := rg.Group("/admin", mw.Timeout, mw.Authenticated, mw.AdminOnly)
+	{
+		admin.GET("/users/search", h.SearchUsers)
+		admin.GET("/users/:id", h.GetUserWithDeleted)
+		admin.POST("/users/:id/restore", h.RestoreUser)
+		admin.PUT("/users/:id/role", h.ChangeUserRole)
+		admin.PUT("/users/:id/status", h.ChangeUserStatus)
+		admin.POST("/users/:id/activate", h.ActivateUser)
+		admin.POST("/users/:id/deactivate", h.DeactivateUser)
+		admin.DELETE("/users", mw.FreshAuth, h.DeleteUsers)
+		admin.DELETE("/users/:id/purge", h.PurgeUser)
+	}
+
+	// Streaming admin routes run without the request timeout
+	adminStream := rg.Group("/admin", mw.Authenticated, mw.AdminOnly)
+	{
+		adminStream.GET("/users/export", h.ExportUsers)
+	}
+}
+
 // Register godoc
 // @Summary Register a new user
 // @Description Register a new user with email and password
@@ -29,23 +88,22 @@ func NewUserHandler(userUseCase usecase.UserUseCase) *UserHandler {
 // @Param request body dto.RegisterRequest true "Register request"
 // @Success 201 {object} response.Response{data=dto.UserResponse}
 // @Failure 400 {object} response.Response
+// @Failure 409 {object} response.Response
 // @Failure 422 {object} response.Response
 // @Router /api/v1/auth/register [post]
 func (h *UserHandler) Register(c *gin.Context) {
 	var req dto.RegisterRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		errors := validator.FormatValidationErrors(err)
-		response.ValidationError(c, errors)
+	if !BindStrict(c, &req) {
 		return
 	}
 
 	user, err := h.userUseCase.Register(c.Request.Context(), &req)
 	if err != nil {
-		response.BadRequest(c, err.Error(), nil)
+		_ = c.Error(err)
 		return
 	}
 
-	response.Created(c, "User registered successfully", user)
+	response.Created(c, i18n.MsgUserRegistered, user)
 }
 
 // Login godoc
@@ -58,22 +116,42 @@ func (h *UserHandler) Register(c *gin.Context) {
 // @Success 200 {object} response.Response{data=dto.LoginResponse}
 // @Failure 400 {object} response.Response
 // @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
 // @Router /api/v1/auth/login [post]
 func (h *UserHandler) Login(c *gin.Context) {
 	var req dto.LoginRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		errors := validator.FormatValidationErrors(err)
-		response.ValidationError(c, errors)
+	if !BindStrict(c, &req) {
 		return
 	}
 
 	result, err := h.userUseCase.Login(c.Request.Context(), &req)
 	if err != nil {
-		response.Unauthorized(c, err.Error())
+		_ = c.Error(err)
+		return
+	}
+
+	response.Success(c, i18n.MsgLoginSuccessful, result)
+}
+
+// Introspect godoc
+// @Summary Introspect token
+// @Description Report whether a token is active and return its claims if it is
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param request body dto.IntrospectRequest true "Introspect request"
+// @Success 200 {object} response.Response{data=dto.IntrospectResponse}
+// @Failure 400 {object} response.Response
+// @Router /api/v1/auth/introspect [post]
+func (h *UserHandler) Introspect(c *gin.Context) {
+	var req dto.IntrospectRequest
+	if !bindJSON(c, &req) {
 		return
 	}
 
-	response.Success(c, "Login successful", result)
+	result := h.userUseCase.Introspect(c.Request.Context(), &req)
+
+	response.Success(c, i18n.MsgTokenIntrospected, result)
 }
 
 // GetUser godoc
@@ -83,24 +161,40 @@ func (h *UserHandler) Login(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path int true "User ID"
+// @Param fields query string false "Comma-separated fields to return, id is always included"
 // @Security BearerAuth
 // @Success 200 {object} response.Response{data=dto.UserResponse}
+// @Success 304 "Not modified, the If-None-Match ETag is current"
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
 // @Failure 404 {object} response.Response
 // @Router /api/v1/users/{id} [get]
 func (h *UserHandler) GetUser(c *gin.Context) {
-	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	id, err := parseIDParam(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	fields, err := fieldset.Bind[dto.UserResponse](c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	user, err := h.userUseCase.GetByID(c.Request.Context(), id)
 	if err != nil {
-		response.BadRequest(c, "Invalid user ID", nil)
+		_ = c.Error(err)
 		return
 	}
 
-	user, err := h.userUseCase.GetByID(c.Request.Context(), uint(id))
+	result, err := fieldset.Select(user, fields)
 	if err != nil {
-		response.NotFound(c, err.Error())
+		_ = c.Error(err)
 		return
 	}
 
-	response.Success(c, "User retrieved successfully", user)
+	response.SuccessWithETag(c, i18n.MsgUserRetrieved, result)
 }
 
 // GetUsers godoc
@@ -111,34 +205,115 @@ func (h *UserHandler) GetUser(c *gin.Context) {
 // @Produce json
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Limit per page" default(10)
+// @Param count query string false "Total: true for an exact count, estimate for a fast estimate, false to omit it" Enums(true, estimate, false) default(true)
+// @Param role query string false "Filter by role" Enums(admin, user)
+// @Param status query string false "Filter by status" Enums(pending, active, suspended, banned)
+// @Param is_active query bool false "Filter by active status (deprecated, use status)"
+// @Param search query string false "Search name or email"
+// @Param fields query string false "Comma-separated fields to return, id is always included"
 // @Security BearerAuth
 // @Success 200 {object} response.Response{data=[]dto.UserResponse}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 422 {object} response.Response
 // @Failure 500 {object} response.Response
 // @Router /api/v1/users [get]
 func (h *UserHandler) GetUsers(c *gin.Context) {
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	var query struct {
+		dto.PaginationRequest
+		dto.UserFilterRequest
+	}
+	if !bindQuery(c, &query) {
+		return
+	}
+	query.Normalize()
+	page, limit := query.Page, query.Limit
+	filter := query.UserFilterRequest
+	mode := pagination.BindTotal(c)
 
-	if page < 1 {
-		page = 1
+	fields, err := fieldset.Bind[dto.UserResponse](c)
+	if err != nil {
+		_ = c.Error(err)
+		return
 	}
-	if limit < 1 || limit > 100 {
-		limit = 10
+
+	users, total, err := h.userUseCase.GetAll(c.Request.Context(), &filter, page, limit, mode)
+	if err != nil {
+		_ = c.Error(err)
+		return
 	}
 
-	users, total, err := h.userUseCase.GetAll(c.Request.Context(), page, limit)
+	result, err := fieldset.SelectAll(users, fields)
 	if err != nil {
-		response.InternalServerError(c, err.Error())
+		_ = c.Error(err)
 		return
 	}
 
-	meta := response.BuildMeta(page, limit, total)
-	response.SuccessWithMeta(c, "Users retrieved successfully", users, meta)
+	response.PaginateWithTotal(c, i18n.MsgUsersRetrieved, result, page, limit, total, mode)
+}
+
+// SearchUsers godoc
+// @Summary Search users
+// @Description Search users by name or email, case-insensitively (admin only). Exact email matches come first, then name or email prefix matches, then other matches.
+// @Tags Admin
+// @Produce json
+// @Param q query string true "Search text"
+// @Param include_deleted query bool false "Also match soft-deleted users" default(false)
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Limit per page" default(10)
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=[]dto.UserResponse}
+// @Failure 400 {object} response.Response
+// @Failure 422 {object} response.Response
+// @Router /api/v1/admin/users/search [get]
+func (h *UserHandler) SearchUsers(c *gin.Context) {
+	var req dto.UserSearchRequest
+	if !bindQuery(c, &req) {
+		return
+	}
+
+	page, limit := pagination.Bind(c)
+
+	users, total, err := h.userUseCase.Search(c.Request.Context(), &req, page, limit)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response.PaginateWithMessage(c, i18n.MsgUsersRetrieved, users, page, limit, total)
+}
+
+// GetUsersBatch godoc
+// @Summary Get users by IDs
+// @Description Get up to 100 users by ID in one request. Users are returned in the requested order, duplicate IDs once, and IDs that do not exist are listed in not_found.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param request body dto.BatchGetUsersRequest true "User IDs"
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=dto.BatchGetUsersResponse}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 422 {object} response.Response
+// @Router /api/v1/users/batch [post]
+func (h *UserHandler) GetUsersBatch(c *gin.Context) {
+	var req dto.BatchGetUsersRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	result, err := h.userUseCase.GetByIDs(c.Request.Context(), req.IDs)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response.Success(c, i18n.MsgUsersRetrieved, result)
 }
 
 // UpdateUser godoc
 // @Summary Update user
-// @Description Update a specific user by ID
+// @Description Replace the profile of a specific user by ID: name and email are required, an omitted password is left unchanged. Use PATCH to change single fields. Non-admins may only update their own account. Send the version from the last read to have a concurrent change rejected with 409 VERSION_CONFLICT.
 // @Tags Users
 // @Accept json
 // @Produce json
@@ -147,55 +322,123 @@ func (h *UserHandler) GetUsers(c *gin.Context) {
 // @Security BearerAuth
 // @Success 200 {object} response.Response{data=dto.UserResponse}
 // @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
 // @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
 // @Router /api/v1/users/{id} [put]
 func (h *UserHandler) UpdateUser(c *gin.Context) {
-	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	id, err := parseIDParam(c, "id")
 	if err != nil {
-		response.BadRequest(c, "Invalid user ID", nil)
+		_ = c.Error(err)
 		return
 	}
 
 	var req dto.UpdateUserRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		errors := validator.FormatValidationErrors(err)
-		response.ValidationError(c, errors)
+	if !BindStrict(c, &req) {
+		return
+	}
+
+	user, err := h.userUseCase.Update(c.Request.Context(), id, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response.Success(c, i18n.MsgUserUpdated, user)
+}
+
+// PatchUser godoc
+// @Summary Patch user
+// @Description Change only the fields present in the body of a specific user by ID; omitted fields are left unchanged and at least one field is required. A null avatar_url removes the avatar, the other fields cannot be null. Non-admins may only update their own account. Send the version from the last read to have a concurrent change rejected with 409 VERSION_CONFLICT.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param request body dto.PatchUserRequest true "Patch user request"
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=dto.UserResponse}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 422 {object} response.Response
+// @Router /api/v1/users/{id} [patch]
+func (h *UserHandler) PatchUser(c *gin.Context) {
+	id, err := parseIDParam(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var req dto.PatchUserRequest
+	if !BindStrict(c, &req) {
 		return
 	}
 
-	user, err := h.userUseCase.Update(c.Request.Context(), uint(id), &req)
+	user, err := h.userUseCase.Patch(c.Request.Context(), id, &req)
 	if err != nil {
-		response.BadRequest(c, err.Error(), nil)
+		_ = c.Error(err)
 		return
 	}
 
-	response.Success(c, "User updated successfully", user)
+	response.Success(c, i18n.MsgUserUpdated, user)
 }
 
 // DeleteUser godoc
 // @Summary Delete user
-// @Description Delete a specific user by ID
+// @Description Delete a specific user by ID. Non-admins may only delete their own account.
 // @Tags Users
 // @Accept json
 // @Produce json
 // @Param id path int true "User ID"
 // @Security BearerAuth
 // @Success 200 {object} response.Response
+// @Failure 403 {object} response.Response
 // @Failure 404 {object} response.Response
 // @Router /api/v1/users/{id} [delete]
 func (h *UserHandler) DeleteUser(c *gin.Context) {
-	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	id, err := parseIDParam(c, "id")
 	if err != nil {
-		response.BadRequest(c, "Invalid user ID", nil)
+		_ = c.Error(err)
+		return
+	}
+
+	if err := h.userUseCase.Delete(c.Request.Context(), id); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response.Success(c, i18n.MsgUserDeleted, nil)
+}
+
+// DeleteUsers godoc
+// @Summary Delete users
+// @Description Delete up to 100 users by ID in one transaction (admin only), softly unless DELETE_MODE is hard. Each ID is reported as deleted or not_found, in the requested order. The batch is rejected if it includes your own account or the last active admin. Each deletion is recorded in the audit log.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param request body dto.BatchDeleteUsersRequest true "User IDs"
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=dto.BatchDeleteUsersResponse}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 422 {object} response.Response
+// @Router /api/v1/admin/users [delete]
+func (h *UserHandler) DeleteUsers(c *gin.Context) {
+	var req dto.BatchDeleteUsersRequest
+	if !bindJSON(c, &req) {
 		return
 	}
 
-	if err := h.userUseCase.Delete(c.Request.Context(), uint(id)); err != nil {
-		response.NotFound(c, err.Error())
+	result, err := h.userUseCase.DeleteByIDs(c.Request.Context(), req.IDs)
+	if err != nil {
+		_ = c.Error(err)
 		return
 	}
 
-	response.Success(c, "User deleted successfully", nil)
+	response.Success(c, i18n.MsgUsersDeleted, result)
 }
 
 // GetCurrentUser godoc
@@ -206,20 +449,440 @@ func (h *UserHandler) DeleteUser(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Success 200 {object} response.Response{data=dto.UserResponse}
+// @Success 304 "Not modified, the If-None-Match ETag is current"
 // @Failure 401 {object} response.Response
 // @Router /api/v1/users/me [get]
 func (h *UserHandler) GetCurrentUser(c *gin.Context) {
 	userID, exists := c.Get("userID")
 	if !exists {
-		response.Unauthorized(c, "User not authenticated")
+		response.Unauthorized(c, i18n.MsgUserNotAuthenticated)
 		return
 	}
 
 	user, err := h.userUseCase.GetByID(c.Request.Context(), userID.(uint))
 	if err != nil {
-		response.NotFound(c, err.Error())
+		_ = c.Error(err)
+		return
+	}
+
+	response.SuccessWithETag(c, i18n.MsgUserRetrieved, user)
+}
+
+// GetCurrentUserPermissions godoc
+// @Summary Get current user permissions
+// @Description Get the roles, permissions and feature flags of the currently authenticated user
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=dto.PermissionsResponse}
+// @Failure 401 {object} response.Response
+// @Router /api/v1/users/me/permissions [get]
+func (h *UserHandler) GetCurrentUserPermissions(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		response.Unauthorized(c, i18n.MsgUserNotAuthenticated)
+		return
+	}
+
+	result := h.userUseCase.Permissions(c.Request.Context(), userID.(uint), c.GetString("userRole"))
+
+	response.Success(c, i18n.MsgPermissionsRetrieved, result)
+}
+
+// LogoutAll godoc
+// @Summary Log out everywhere
+// @Description Revoke every token issued to the current user, including the one used for this request
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Router /api/v1/users/me/logout-all [post]
+func (h *UserHandler) LogoutAll(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		response.Unauthorized(c, i18n.MsgUserNotAuthenticated)
+		return
+	}
+
+	if err := h.userUseCase.LogoutAll(c.Request.Context(), userID.(uint)); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response.Success(c, i18n.MsgLoggedOutAll, nil)
+}
+
+// GetUserWithDeleted godoc
+// @Summary Get user including deleted
+// @Description Get a specific user by ID, including soft-deleted users (admin only)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=dto.UserResponse}
+// @Failure 404 {object} response.Response
+// @Router /api/v1/admin/users/{id} [get]
+func (h *UserHandler) GetUserWithDeleted(c *gin.Context) {
+	id, err := parseIDParam(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	user, err := h.userUseCase.GetByIDWithDeleted(c.Request.Context(), id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response.Success(c, i18n.MsgUserRetrieved, user)
+}
+
+// RestoreUser godoc
+// @Summary Restore user
+// @Description Restore a soft-deleted user (admin only)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=dto.UserResponse}
+// @Failure 404 {object} response.Response
+// @Router /api/v1/admin/users/{id}/restore [post]
+func (h *UserHandler) RestoreUser(c *gin.Context) {
+	id, err := parseIDParam(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	user, err := h.userUseCase.Restore(c.Request.Context(), id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response.Success(c, i18n.MsgUserRestored, user)
+}
+
+// ChangeUserRole godoc
+// @Summary Change user role
+// @Description Give a user another role (admin only). The user's tokens are revoked, so they log in again with the new role. The last active admin cannot be demoted.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param request body dto.ChangeRoleRequest true "New role"
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=dto.UserResponse}
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Router /api/v1/admin/users/{id}/role [put]
+func (h *UserHandler) ChangeUserRole(c *gin.Context) {
+	id, err := parseIDParam(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var req dto.ChangeRoleRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	user, err := h.userUseCase.ChangeRole(c.Request.Context(), id, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response.Success(c, i18n.MsgUserRoleChanged, user)
+}
+
+// ChangeUserStatus godoc
+// @Summary Change user status
+// @Description Move a user to another status (admin only). Only active users may log in, and leaving the active status revokes the user's tokens. Admins cannot change their own status.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param request body dto.ChangeStatusRequest true "New status"
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=dto.UserResponse}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/admin/users/{id}/status [put]
+func (h *UserHandler) ChangeUserStatus(c *gin.Context) {
+	id, err := parseIDParam(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var req dto.ChangeStatusRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	user, err := h.userUseCase.ChangeStatus(c.Request.Context(), id, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response.Success(c, i18n.MsgUserStatusChanged, user)
+}
+
+// ActivateUser godoc
+// @Summary Activate user
+// @Description Set a user's status to active so they can log in again (admin only). Admins cannot activate themselves.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=dto.UserResponse}
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/admin/users/{id}/activate [post]
+func (h *UserHandler) ActivateUser(c *gin.Context) {
+	id, err := parseIDParam(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	user, err := h.userUseCase.Activate(c.Request.Context(), id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response.Success(c, i18n.MsgUserActivated, user)
+}
+
+// DeactivateUser godoc
+// @Summary Deactivate user
+// @Description Suspend a user without deleting them, so they can no longer log in (admin only). Their tokens are revoked unless revoke_tokens is false. Admins cannot deactivate themselves.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param revoke_tokens query bool false "Revoke the user's tokens" default(true)
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=dto.UserResponse}
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 422 {object} response.Response
+// @Router /api/v1/admin/users/{id}/deactivate [post]
+func (h *UserHandler) DeactivateUser(c *gin.Context) {
+	id, err := parseIDParam(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var req dto.DeactivateUserRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		errors := validator.FormatValidationErrors(err, i18n.FromContext(c))
+		response.ValidationError(c, errors)
+		return
+	}
+
+	user, err := h.userUseCase.Deactivate(c.Request.Context(), id, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response.Success(c, i18n.MsgUserDeactivated, user)
+}
+
+// PurgeUser godoc
+// @Summary Purge user
+// @Description Permanently delete a user (admin only). The request body must confirm the user's email.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param request body dto.PurgeUserRequest true "Purge confirmation"
+// @Security BearerAuth
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/admin/users/{id}/purge [delete]
+func (h *UserHandler) PurgeUser(c *gin.Context) {
+	id, err := parseIDParam(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var req dto.PurgeUserRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	actorID := c.GetUint("userID")
+	actorRole := c.GetString("userRole")
+
+	if err := h.userUseCase.Purge(c.Request.Context(), id, actorID, actorRole, &req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response.Success(c, i18n.MsgUserPurged, nil)
+}
+
+// ExportUsers godoc
+// @Summary Export users
+// @Description Stream users matching the list filters as a CSV download (admin only)
+// @Tags Admin
+// @Produce text/csv
+// @Param role query string false "Filter by role" Enums(admin, user)
+// @Param status query string false "Filter by status" Enums(pending, active, suspended, banned)
+// @Param is_active query bool false "Filter by active status (deprecated, use status)"
+// @Param search query string false "Search name or email"
+// @Param include_deleted query bool false "Also export soft-deleted users" default(false)
+// @Security BearerAuth
+// @Success 200 {file} file
+// @Failure 422 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /api/v1/admin/users/export [get]
+func (h *UserHandler) ExportUsers(c *gin.Context) {
+	var req dto.UserExportRequest
+	if !bindQuery(c, &req) {
+		return
+	}
+
+	// Large exports take longer than the server's write timeout
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		logger.Warnf("Failed to clear write deadline for user export: %v", err)
+	}
+
+	// The producer stops as soon as the stream ends, even if the client is still connected
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+
+	batches := make(chan []dto.UserResponse)
+	// exportErr receives the result of the export before batches is closed
+	exportErr := make(chan error, 1)
+	go func() {
+		defer close(batches)
+		exportErr <- h.userUseCase.Export(ctx, &req, func(batch []dto.UserResponse) error {
+			select {
+			case batches <- batch:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}()
+
+	// Wait for the first batch before answering, so an export that fails
+	// at the start gets an error response instead of an empty CSV
+	first, more := <-batches
+	if !more {
+		if err := <-exportErr; err != nil {
+			_ = c.Error(err)
+			return
+		}
+	}
+
+	filename := fmt.Sprintf("users-%s.csv", time.Now().UTC().Format("20060102-150405"))
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+	writer := csv.NewWriter(c.Writer)
+	_ = writer.Write([]string{"id", "name", "email", "role", "status", "is_active", "created_at", "deleted_at"})
+	writeUserRows(writer, first)
+	writer.Flush()
+	if !more || writer.Error() != nil {
+		return
+	}
+
+	c.Stream(func(w io.Writer) bool {
+		batch, ok := <-batches
+		if !ok {
+			// Past the first batch the status is sent, so failures can only be logged
+			if err := <-exportErr; err != nil && ctx.Err() == nil {
+				logger.WithField("error", err).Error("User export failed")
+			}
+			return false
+		}
+
+		writeUserRows(writer, batch)
+		writer.Flush()
+		return writer.Error() == nil
+	})
+}
+
+// writeUserRows writes one CSV row per user
+func writeUserRows(writer *csv.Writer, users []dto.UserResponse) {
+	for _, user := range users {
+		_ = writer.Write([]string{
+			strconv.FormatUint(uint64(user.ID), 10),
+			csvSafe(user.Name),
+			csvSafe(user.Email),
+			user.Role,
+			user.Status,
+			strconv.FormatBool(user.IsActive),
+			user.CreatedAt.UTC().Format(time.RFC3339),
+			csvTime(user.DeletedAt),
+		})
+	}
+}
+
+// UploadAvatar godoc
+// @Summary Upload avatar
+// @Description Upload a JPEG, PNG or GIF avatar for the current user
+// @Tags Users
+// @Accept multipart/form-data
+// @Produce json
+// @Param avatar formData file true "Avatar image"
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=dto.UserResponse}
+// @Failure 400 {object} response.Response
+// @Failure 413 {object} response.Response
+// @Failure 422 {object} response.Response
+// @Router /api/v1/users/me/avatar [post]
+func (h *UserHandler) UploadAvatar(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		response.Unauthorized(c, i18n.MsgUserNotAuthenticated)
+		return
+	}
+
+	header, err := c.FormFile("avatar")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			_ = c.Error(apperrors.ErrPayloadTooLarge)
+			return
+		}
+		_ = c.Error(apperrors.NewAppError(http.StatusBadRequest, apperrors.SlugBadRequest, "Avatar file is required", err))
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		_ = c.Error(apperrors.WrapError(apperrors.ErrInternalServer, err))
+		return
+	}
+	defer file.Close()
+
+	user, err := h.userUseCase.UploadAvatar(c.Request.Context(), userID.(uint), file, header.Size)
+	if err != nil {
+		_ = c.Error(err)
 		return
 	}
 
-	response.Success(c, "User retrieved successfully", user)
+	response.Success(c, i18n.MsgAvatarUploaded, user)
 }
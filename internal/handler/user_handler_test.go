@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-username/go-clean-architecture/internal/dto"
+	"github.com/your-username/go-clean-architecture/internal/middleware"
+	"github.com/your-username/go-clean-architecture/internal/usecase"
+	"github.com/your-username/go-clean-architecture/pkg/apperrors"
+	"github.com/your-username/go-clean-architecture/pkg/logger"
+)
+
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+	logger.InitLogger(false)
+	os.Exit(m.Run())
+}
+
+// slowExportUseCase exports batches of one user, pausing before each.
+// Methods the tests do not need panic if called.
+type slowExportUseCase struct {
+	usecase.UserUseCase
+	batches int
+	pause   time.Duration
+}
+
+func (u *slowExportUseCase) Export(ctx context.Context, _ *dto.UserExportRequest, fn func([]dto.UserResponse) error) error {
+	for i := 1; i <= u.batches; i++ {
+		select {
+		case <-time.After(u.pause):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if err := fn([]dto.UserResponse{{ID: uint(i), Name: "User", Email: "user@example.com"}}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestExportUsersOutlivesWriteTimeout(t *testing.T) {
+	h := NewUserHandler(&slowExportUseCase{batches: 5, pause: 50 * time.Millisecond})
+	router := gin.New()
+	router.GET("/admin/users/export", h.ExportUsers)
+
+	server := httptest.NewUnstartedServer(router)
+	// The export takes about 250ms, well past the write timeout
+	server.Config.WriteTimeout = 100 * time.Millisecond
+	server.Start()
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/admin/users/export")
+	if err != nil {
+		t.Fatalf("GET export error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("reading export error = %v", err)
+	}
+	records, err := csv.NewReader(bytes.NewReader(body)).ReadAll()
+	if err != nil {
+		t.Fatalf("parsing export error = %v", err)
+	}
+	// The header and one row per user
+	if len(records) != 6 {
+		t.Errorf("export has %d records, want 6", len(records))
+	}
+}
+
+// failingExportUseCase exports batches of one user, then fails
+type failingExportUseCase struct {
+	usecase.UserUseCase
+	batches int
+	err     error
+}
+
+func (u *failingExportUseCase) Export(_ context.Context, _ *dto.UserExportRequest, fn func([]dto.UserResponse) error) error {
+	for i := 1; i <= u.batches; i++ {
+		if err := fn([]dto.UserResponse{{ID: uint(i), Name: "User", Email: "user@example.com"}}); err != nil {
+			return err
+		}
+	}
+	return u.err
+}
+
+func TestExportUsersReportsFailures(t *testing.T) {
+	tests := []struct {
+		name        string
+		batches     int
+		err         error
+		wantStatus  int
+		wantCSV     bool
+		wantRecords int
+	}{
+		{name: "fails at the start", err: apperrors.ErrQueryTimeout, wantStatus: http.StatusGatewayTimeout},
+		{name: "no users", wantStatus: http.StatusOK, wantCSV: true, wantRecords: 1},
+		{name: "all users", batches: 2, wantStatus: http.StatusOK, wantCSV: true, wantRecords: 3},
+		// Once rows are sent the status cannot change, the CSV is cut short
+		{name: "fails midway", batches: 2, err: apperrors.ErrQueryTimeout, wantStatus: http.StatusOK, wantCSV: true, wantRecords: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewUserHandler(&failingExportUseCase{batches: tt.batches, err: tt.err})
+			router := gin.New()
+			router.Use(middleware.ErrorMiddleware(false))
+			router.GET("/admin/users/export", h.ExportUsers)
+
+			// Streaming needs a real connection, a recorder cannot notify of its close
+			server := httptest.NewServer(router)
+			defer server.Close()
+
+			resp, err := http.Get(server.URL + "/admin/users/export")
+			if err != nil {
+				t.Fatalf("GET export error = %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			isCSV := strings.HasPrefix(resp.Header.Get("Content-Type"), "text/csv")
+			if isCSV != tt.wantCSV {
+				t.Fatalf("Content-Type = %q, want CSV %v", resp.Header.Get("Content-Type"), tt.wantCSV)
+			}
+			if !tt.wantCSV {
+				return
+			}
+			records, err := csv.NewReader(resp.Body).ReadAll()
+			if err != nil {
+				t.Fatalf("parsing export error = %v", err)
+			}
+			if len(records) != tt.wantRecords {
+				t.Errorf("export has %d records, want %d", len(records), tt.wantRecords)
+			}
+		})
+	}
+}
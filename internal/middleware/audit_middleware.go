@@ -0,0 +1,15 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/your-username/go-clean-architecture/pkg/audit"
+)
+
+// AuditContextMiddleware creates a middleware that stores the client IP in
+// the request context for the audit trail
+func AuditContextMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(audit.WithClientIP(c.Request.Context(), c.ClientIP()))
+		c.Next()
+	}
+}
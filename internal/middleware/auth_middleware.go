@@ -1,19 +1,34 @@
 package middleware
 
 import (
+	"context"
+	"errors"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/your-username/go-clean-architecture/pkg/apperrors"
+	"github.com/your-username/go-clean-architecture/pkg/audit"
+	"github.com/your-username/go-clean-architecture/pkg/auth"
+	"github.com/your-username/go-clean-architecture/pkg/i18n"
+	"github.com/your-username/go-clean-architecture/pkg/logger"
 	"github.com/your-username/go-clean-architecture/pkg/response"
 	"github.com/your-username/go-clean-architecture/pkg/utils"
 )
 
-// AuthMiddleware creates a new authentication middleware
-func AuthMiddleware(jwtManager *utils.JWTManager) gin.HandlerFunc {
+// TokenVersionSource reports a user's current token version
+type TokenVersionSource interface {
+	TokenVersion(ctx context.Context, userID uint) (uint, error)
+}
+
+// AuthMiddleware creates a new authentication middleware. Tokens whose
+// version is older than the user's current one have been revoked and are
+// rejected, as are tokens of users that no longer exist.
+func AuthMiddleware(jwtManager *utils.JWTManager, versions TokenVersionSource) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
-			response.Unauthorized(c, "Authorization header is required")
+			response.Unauthorized(c, i18n.MsgAuthHeaderRequired)
 			c.Abort()
 			return
 		}
@@ -21,7 +36,7 @@ func AuthMiddleware(jwtManager *utils.JWTManager) gin.HandlerFunc {
 		// Check Bearer token format
 		parts := strings.Split(authHeader, " ")
 		if len(parts) != 2 || parts[0] != "Bearer" {
-			response.Unauthorized(c, "Invalid authorization header format")
+			response.Unauthorized(c, i18n.MsgAuthHeaderInvalid)
 			c.Abort()
 			return
 		}
@@ -31,7 +46,24 @@ func AuthMiddleware(jwtManager *utils.JWTManager) gin.HandlerFunc {
 		// Validate token
 		claims, err := jwtManager.ValidateToken(tokenString)
 		if err != nil {
-			response.Unauthorized(c, "Invalid or expired token")
+			logger.WithContext(c.Request.Context()).Debugf("Token rejected: %v", err)
+			response.Unauthorized(c, i18n.MsgTokenInvalid)
+			c.Abort()
+			return
+		}
+
+		version, err := versions.TokenVersion(c.Request.Context(), claims.UserID)
+		if err != nil {
+			if errors.Is(err, apperrors.ErrUserNotFound) {
+				response.Unauthorized(c, i18n.MsgTokenInvalid)
+			} else {
+				response.FromError(c, err)
+			}
+			c.Abort()
+			return
+		}
+		if version != claims.TokenVersion {
+			response.FromError(c, apperrors.ErrTokenRevoked)
 			c.Abort()
 			return
 		}
@@ -40,6 +72,35 @@ func AuthMiddleware(jwtManager *utils.JWTManager) gin.HandlerFunc {
 		c.Set("userID", claims.UserID)
 		c.Set("userEmail", claims.Email)
 		c.Set("userRole", claims.Role)
+		c.Set("tokenIssuedAt", claims.IssuedAt.Time)
+		ctx := auth.WithUser(c.Request.Context(), &auth.AuthUser{
+			ID:    claims.UserID,
+			Email: claims.Email,
+			Role:  claims.Role,
+		})
+		c.Request = c.Request.WithContext(audit.WithActor(ctx, claims.UserID))
+
+		c.Next()
+	}
+}
+
+// RequireFreshAuth creates a middleware for sensitive actions that rejects
+// tokens issued more than maxAge ago, so clients ask the user to log in
+// again. It must run after AuthMiddleware, which guarantees the iat claim.
+func RequireFreshAuth(maxAge time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		issuedAt, ok := c.Get("tokenIssuedAt")
+		if !ok {
+			response.Unauthorized(c, i18n.MsgTokenIssueTimeMissing)
+			c.Abort()
+			return
+		}
+
+		if time.Since(issuedAt.(time.Time)) > maxAge {
+			response.FromError(c, apperrors.ErrReauthRequired)
+			c.Abort()
+			return
+		}
 
 		c.Next()
 	}
@@ -50,7 +111,7 @@ func RoleMiddleware(allowedRoles ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		userRole, exists := c.Get("userRole")
 		if !exists {
-			response.Unauthorized(c, "User role not found")
+			response.Unauthorized(c, i18n.MsgUserRoleMissing)
 			c.Abort()
 			return
 		}
@@ -63,7 +124,7 @@ func RoleMiddleware(allowedRoles ...string) gin.HandlerFunc {
 			}
 		}
 
-		response.Forbidden(c, "You don't have permission to access this resource")
+		response.Forbidden(c, i18n.MsgPermissionDenied)
 		c.Abort()
 	}
 }
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// bodyLimitOriginalKey stores the unlimited request body so a route-level
+// limit can replace, rather than stack on top of, the global one
+const bodyLimitOriginalKey = "bodyLimitOriginalBody"
+
+// BodyLimitMiddleware creates a middleware that caps the request body at
+// maxBytes. Reading past the limit fails with http.MaxBytesError, which
+// handlers report as 413. The check happens on read rather than on
+// Content-Length so that applying the middleware again on a route can raise
+// the global limit. A non-positive maxBytes disables the limit.
+func BodyLimitMiddleware(maxBytes int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Body == nil {
+			c.Next()
+			return
+		}
+
+		body := c.Request.Body
+		if original, exists := c.Get(bodyLimitOriginalKey); exists {
+			body = original.(io.ReadCloser)
+		} else {
+			c.Set(bodyLimitOriginalKey, body)
+		}
+
+		if maxBytes <= 0 {
+			c.Request.Body = body
+			c.Next()
+			return
+		}
+
+		c.Request.Body = http.MaxBytesReader(c.Writer, body, maxBytes)
+		c.Next()
+	}
+}
@@ -0,0 +1,180 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/your-username/go-clean-architecture/config"
+	"github.com/your-username/go-clean-architecture/pkg/logger"
+)
+
+// redactedValue replaces the value of a redacted field
+const redactedValue = "[REDACTED]"
+
+// BodyLogMiddleware creates a middleware that logs JSON request and response
+// bodies for debugging. Bodies are captured while the handler reads and
+// writes them, up to cfg.BodyMaxBytes, so they are never buffered whole and
+// body limits still apply. Values of cfg.RedactFields are masked. Multipart
+// and other non-JSON bodies are never logged.
+func BodyLogMiddleware(cfg config.LogConfig) gin.HandlerFunc {
+	redactor := newBodyRedactor(cfg.RedactFields)
+
+	return func(c *gin.Context) {
+		var request *bodyCapture
+		if c.Request.Body != nil && isJSONContentType(c.ContentType()) {
+			request = &bodyCapture{limit: cfg.BodyMaxBytes}
+			c.Request.Body = &capturingBody{ReadCloser: c.Request.Body, capture: request}
+		}
+
+		writer := &capturingWriter{ResponseWriter: c.Writer, capture: &bodyCapture{limit: cfg.BodyMaxBytes}}
+		c.Writer = writer
+
+		c.Next()
+		c.Writer = writer.ResponseWriter
+
+		fields := logrus.Fields{
+			"method":      c.Request.Method,
+			"path":        c.Request.URL.Path,
+			"status_code": c.Writer.Status(),
+		}
+		if request != nil {
+			fields["request_body"] = redactor.format(request)
+		}
+		if isJSONContentType(writer.Header().Get("Content-Type")) {
+			fields["response_body"] = redactor.format(writer.capture)
+		}
+		logger.WithContext(c.Request.Context()).WithFields(fields).Info("Request bodies")
+	}
+}
+
+// isJSONContentType reports whether a Content-Type value denotes JSON
+func isJSONContentType(contentType string) bool {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// bodyCapture keeps the first limit bytes of a body
+type bodyCapture struct {
+	buf       bytes.Buffer
+	limit     int
+	size      int
+	truncated bool
+}
+
+// write records p, dropping whatever exceeds the limit
+func (b *bodyCapture) write(p []byte) {
+	b.size += len(p)
+	remaining := b.limit - b.buf.Len()
+	if len(p) > remaining {
+		b.truncated = true
+		p = p[:max(remaining, 0)]
+	}
+	b.buf.Write(p)
+}
+
+// capturingBody copies a request body as it is read
+type capturingBody struct {
+	io.ReadCloser
+	capture *bodyCapture
+}
+
+// Read implements io.Reader
+func (r *capturingBody) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.capture.write(p[:n])
+	return n, err
+}
+
+// capturingWriter copies a response body as it is written
+type capturingWriter struct {
+	gin.ResponseWriter
+	capture *bodyCapture
+}
+
+// Write implements http.ResponseWriter
+func (w *capturingWriter) Write(data []byte) (int, error) {
+	w.capture.write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+// WriteString implements gin.ResponseWriter
+func (w *capturingWriter) WriteString(s string) (int, error) {
+	w.capture.write([]byte(s))
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Unwrap returns the wrapped writer for http.ResponseController
+func (w *capturingWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// bodyRedactor masks sensitive fields in captured JSON bodies
+type bodyRedactor struct {
+	fields map[string]bool
+	// pattern masks fields in bodies that cannot be parsed, such as
+	// truncated ones
+	pattern *regexp.Regexp
+}
+
+// newBodyRedactor creates a redactor for the given field names, matched
+// case-insensitively
+func newBodyRedactor(fields []string) *bodyRedactor {
+	r := &bodyRedactor{fields: make(map[string]bool, len(fields))}
+	quoted := make([]string, 0, len(fields))
+	for _, field := range fields {
+		r.fields[strings.ToLower(field)] = true
+		quoted = append(quoted, regexp.QuoteMeta(field))
+	}
+	if len(quoted) > 0 {
+		r.pattern = regexp.MustCompile(`(?i)("(?:` + strings.Join(quoted, "|") + `)"\s*:\s*)("(?:[^"\\]|\\.)*"?|[^,}\]\s]+)`)
+	}
+	return r
+}
+
+// format returns the captured body with sensitive fields masked
+func (r *bodyRedactor) format(body *bodyCapture) string {
+	data := body.buf.Bytes()
+
+	var result string
+	var parsed interface{}
+	if !body.truncated && json.Unmarshal(data, &parsed) == nil {
+		masked, _ := json.Marshal(r.redact(parsed))
+		result = string(masked)
+	} else if r.pattern != nil {
+		result = r.pattern.ReplaceAllString(string(data), `${1}"`+redactedValue+`"`)
+	} else {
+		result = string(data)
+	}
+
+	if body.truncated {
+		result += fmt.Sprintf("... (truncated, %d bytes)", body.size)
+	}
+	return result
+}
+
+// redact masks sensitive fields of a decoded JSON value in place
+func (r *bodyRedactor) redact(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if r.fields[strings.ToLower(key)] {
+				v[key] = redactedValue
+				continue
+			}
+			v[key] = r.redact(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = r.redact(item)
+		}
+	}
+	return value
+}
@@ -0,0 +1,238 @@
+package middleware
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-username/go-clean-architecture/config"
+)
+
+// incompressibleTypes lists content type prefixes that are already compressed
+// or must not be buffered
+var incompressibleTypes = []string{
+	"image/",
+	"video/",
+	"audio/",
+	"font/woff",
+	"application/zip",
+	"application/gzip",
+	"application/x-gzip",
+	"application/octet-stream",
+	"text/event-stream",
+}
+
+// CompressionMiddleware creates a middleware that gzip or deflate encodes
+// responses when the client accepts it and the body reaches cfg.MinSize.
+func CompressionMiddleware(cfg config.CompressionConfig) gin.HandlerFunc {
+	level := cfg.Level
+	if level < gzip.HuffmanOnly || level > gzip.BestCompression {
+		level = gzip.DefaultCompression
+	}
+
+	gzipPool := &sync.Pool{New: func() interface{} {
+		w, _ := gzip.NewWriterLevel(io.Discard, level)
+		return w
+	}}
+	flatePool := &sync.Pool{New: func() interface{} {
+		w, _ := flate.NewWriter(io.Discard, level)
+		return w
+	}}
+
+	return func(c *gin.Context) {
+		if !cfg.Enabled {
+			c.Next()
+			return
+		}
+
+		c.Writer.Header().Add("Vary", "Accept-Encoding")
+
+		encoding := negotiateEncoding(c.GetHeader("Accept-Encoding"))
+		if encoding == "" || c.Request.Method == http.MethodHead {
+			c.Next()
+			return
+		}
+
+		original := c.Writer
+		cw := &compressWriter{
+			ResponseWriter: original,
+			encoding:       encoding,
+			minSize:        cfg.MinSize,
+			gzipPool:       gzipPool,
+			flatePool:      flatePool,
+		}
+		c.Writer = cw
+
+		defer func() {
+			cw.finish()
+			c.Writer = original
+		}()
+
+		c.Next()
+	}
+}
+
+// negotiateEncoding picks gzip or deflate from an Accept-Encoding header
+func negotiateEncoding(header string) string {
+	accepted := make(map[string]bool)
+	for _, part := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+
+		q := 1.0
+		if _, value, found := strings.Cut(params, "q="); found {
+			if parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+				q = parsed
+			}
+		}
+		accepted[name] = q > 0
+	}
+
+	switch {
+	case accepted["gzip"]:
+		return "gzip"
+	case accepted["deflate"]:
+		return "deflate"
+	default:
+		return ""
+	}
+}
+
+// compressWriter buffers the response until it knows whether compression pays off
+type compressWriter struct {
+	gin.ResponseWriter
+	encoding    string
+	minSize     int
+	gzipPool    *sync.Pool
+	flatePool   *sync.Pool
+	buf         []byte
+	encoder     io.WriteCloser
+	passthrough bool
+}
+
+// Write implements http.ResponseWriter
+func (w *compressWriter) Write(data []byte) (int, error) {
+	if w.passthrough {
+		return w.ResponseWriter.Write(data)
+	}
+	if w.encoder != nil {
+		return w.encoder.Write(data)
+	}
+
+	if !w.compressible() {
+		w.passthrough = true
+		if err := w.flushBuffer(); err != nil {
+			return 0, err
+		}
+		return w.ResponseWriter.Write(data)
+	}
+
+	w.buf = append(w.buf, data...)
+	if len(w.buf) >= w.minSize {
+		if err := w.startEncoder(); err != nil {
+			return 0, err
+		}
+	}
+	return len(data), nil
+}
+
+// WriteString implements gin.ResponseWriter
+func (w *compressWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Written reports true once the handler has produced output, even if buffered
+func (w *compressWriter) Written() bool {
+	return len(w.buf) > 0 || w.encoder != nil || w.ResponseWriter.Written()
+}
+
+// Flush sends buffered data to the client so streaming responses keep working
+func (w *compressWriter) Flush() {
+	if w.encoder == nil && !w.passthrough {
+		w.passthrough = true
+		_ = w.flushBuffer()
+	}
+	if f, ok := w.encoder.(interface{ Flush() error }); ok {
+		_ = f.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// Unwrap returns the wrapped writer for http.ResponseController
+func (w *compressWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// compressible checks whether the response headers allow compression
+func (w *compressWriter) compressible() bool {
+	header := w.Header()
+	if header.Get("Content-Encoding") != "" {
+		return false
+	}
+
+	contentType := strings.ToLower(header.Get("Content-Type"))
+	if contentType == "" {
+		return false
+	}
+	for _, prefix := range incompressibleTypes {
+		if strings.HasPrefix(contentType, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// startEncoder switches the response to compressed output
+func (w *compressWriter) startEncoder() error {
+	header := w.Header()
+	header.Del("Content-Length")
+	header.Set("Content-Encoding", w.encoding)
+
+	if w.encoding == "gzip" {
+		gz := w.gzipPool.Get().(*gzip.Writer)
+		gz.Reset(w.ResponseWriter)
+		w.encoder = gz
+	} else {
+		fl := w.flatePool.Get().(*flate.Writer)
+		fl.Reset(w.ResponseWriter)
+		w.encoder = fl
+	}
+
+	buf := w.buf
+	w.buf = nil
+	_, err := w.encoder.Write(buf)
+	return err
+}
+
+// flushBuffer writes any buffered bytes uncompressed
+func (w *compressWriter) flushBuffer() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+	buf := w.buf
+	w.buf = nil
+	_, err := w.ResponseWriter.Write(buf)
+	return err
+}
+
+// finish closes the encoder or writes a small body as-is
+func (w *compressWriter) finish() {
+	if w.encoder == nil {
+		_ = w.flushBuffer()
+		return
+	}
+
+	_ = w.encoder.Close()
+	switch enc := w.encoder.(type) {
+	case *gzip.Writer:
+		w.gzipPool.Put(enc)
+	case *flate.Writer:
+		w.flatePool.Put(enc)
+	}
+	w.encoder = nil
+}
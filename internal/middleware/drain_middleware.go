@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/your-username/go-clean-architecture/pkg/apperrors"
+	"github.com/your-username/go-clean-architecture/pkg/response"
+)
+
+// ShutdownSignal reports whether the server is shutting down
+type ShutdownSignal interface {
+	ShuttingDown() bool
+}
+
+// DrainMiddleware creates a middleware that, once the server is shutting
+// down, answers requests with a 503 and Connection: close, so the readiness
+// probe fails and the load balancer stops routing to this instance while
+// in-flight requests finish. Requests for the skipped paths, such as the
+// liveness probe, are still served.
+func DrainMiddleware(signal ShutdownSignal, skipPaths ...string) gin.HandlerFunc {
+	skip := make(map[string]bool, len(skipPaths))
+	for _, path := range skipPaths {
+		skip[path] = true
+	}
+
+	return func(c *gin.Context) {
+		if !signal.ShuttingDown() || skip[c.Request.URL.Path] {
+			c.Next()
+			return
+		}
+
+		c.Header("Connection", "close")
+		response.FromError(c, apperrors.ErrShuttingDown)
+		c.Abort()
+	}
+}
@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/your-username/go-clean-architecture/pkg/apperrors"
+	"github.com/your-username/go-clean-architecture/pkg/i18n"
+	"github.com/your-username/go-clean-architecture/pkg/logger"
+	"github.com/your-username/go-clean-architecture/pkg/response"
+)
+
+// debugStackFrames limits the stack frames included in debug responses
+const debugStackFrames = 10
+
+// ErrorMiddleware creates a middleware that writes the standard error envelope
+// for errors attached with c.Error. The last error wins, and its status code
+// and message are derived from apperrors.AppError. Captured stack traces are
+// logged, and in debug mode a truncated stack is included in the response.
+func ErrorMiddleware(debug bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		err := c.Errors.Last().Err
+		appErr := apperrors.GetAppError(err)
+
+		if stack := appErr.StackTrace(); stack != "" {
+			entry := logger.WithFields(logrus.Fields{
+				"path":  c.Request.URL.Path,
+				"stack": stack,
+			})
+			if appErr.Code >= 500 {
+				entry.Errorf("Request failed: %v", err)
+			} else {
+				entry.Debugf("Request failed: %v", err)
+			}
+		}
+
+		if debug {
+			if frames := appErr.StackFrames(debugStackFrames); len(frames) > 0 {
+				response.ErrorWithCode(c, appErr.Code, appErr.Slug, i18n.Key(appErr.Message), gin.H{
+					"detail": err.Error(),
+					"stack":  frames,
+				})
+				return
+			}
+		}
+
+		response.FromError(c, err)
+	}
+}
@@ -0,0 +1,186 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	"github.com/your-username/go-clean-architecture/config"
+	"github.com/your-username/go-clean-architecture/pkg/apperrors"
+	"github.com/your-username/go-clean-architecture/pkg/database"
+	"github.com/your-username/go-clean-architecture/pkg/i18n"
+	"github.com/your-username/go-clean-architecture/pkg/logger"
+	"github.com/your-username/go-clean-architecture/pkg/response"
+)
+
+const (
+	// idempotencyHeader is the request header carrying the client's key
+	idempotencyHeader = "Idempotency-Key"
+	// idempotencyReplayedHeader marks responses served from the cache
+	idempotencyReplayedHeader = "Idempotency-Replayed"
+	// maxIdempotencyKeyLength bounds the accepted key length
+	maxIdempotencyKeyLength = 255
+	// idempotencyRedisTimeout bounds each Redis call
+	idempotencyRedisTimeout = 2 * time.Second
+)
+
+// idempotentResponse is the cached response for an idempotency key
+type idempotentResponse struct {
+	Fingerprint string `json:"fingerprint"`
+	Status      int    `json:"status"`
+	ContentType string `json:"content_type"`
+	Body        []byte `json:"body"`
+}
+
+// IdempotencyMiddleware creates a middleware that replays the first response
+// for a repeated Idempotency-Key on unsafe methods. Keys are scoped to the
+// authenticated user and route, and reusing a key with a different body is
+// rejected. Requests without the header, or when Redis is unavailable, pass
+// straight through.
+func IdempotencyMiddleware(client *database.RedisClient, cfg config.IdempotencyConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(idempotencyHeader)
+		if client == nil || key == "" || !isUnsafeMethod(c.Request.Method) {
+			c.Next()
+			return
+		}
+
+		if len(key) > maxIdempotencyKeyLength {
+			response.BadRequest(c, i18n.Key(fmt.Sprintf("%s must be at most %d characters", idempotencyHeader, maxIdempotencyKeyLength)), nil)
+			c.Abort()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			// The body limit runs first, report a body over it as such
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				response.FromError(c, apperrors.ErrPayloadTooLarge)
+			} else {
+				response.BadRequest(c, i18n.MsgRequestBodyUnreadable, nil)
+			}
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		fingerprint := sha256.Sum256(body)
+		fp := hex.EncodeToString(fingerprint[:])
+		cacheKey := idempotencyCacheKey(c, key)
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), idempotencyRedisTimeout)
+		defer cancel()
+
+		// Replay a completed response
+		cached, err := client.Get(ctx, cacheKey)
+		switch {
+		case err == nil:
+			var stored idempotentResponse
+			if jsonErr := json.Unmarshal([]byte(cached), &stored); jsonErr == nil {
+				if stored.Fingerprint != fp {
+					response.FromError(c, apperrors.ErrIdempotencyReuse)
+					c.Abort()
+					return
+				}
+				c.Header(idempotencyReplayedHeader, "true")
+				c.Data(stored.Status, stored.ContentType, stored.Body)
+				c.Abort()
+				return
+			}
+		case !errors.Is(err, redis.Nil):
+			logger.Warnf("Idempotency lookup failed, processing request normally: %v", err)
+			c.Next()
+			return
+		}
+
+		// Claim the key for this request. The lock holds a token of its own,
+		// so if it expires while the handler runs, releasing it cannot free
+		// the lock of a request that claimed the key since.
+		unlock, acquired, err := client.AcquireLock(ctx, cacheKey, cfg.LockTTL)
+		if err != nil {
+			logger.Warnf("Idempotency lock failed, processing request normally: %v", err)
+			c.Next()
+			return
+		}
+		if !acquired {
+			response.FromError(c, apperrors.ErrIdempotencyBusy)
+			c.Abort()
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+
+		c.Next()
+		c.Writer = recorder.ResponseWriter
+
+		// Use a fresh context, the request context may already be done
+		storeCtx, storeCancel := context.WithTimeout(context.Background(), idempotencyRedisTimeout)
+		defer storeCancel()
+
+		// Only cache responses the handler wrote itself and that are not
+		// server errors, so the client can safely retry those
+		if recorder.Written() && recorder.Status() < http.StatusInternalServerError {
+			stored, _ := json.Marshal(idempotentResponse{
+				Fingerprint: fp,
+				Status:      recorder.Status(),
+				ContentType: recorder.Header().Get("Content-Type"),
+				Body:        recorder.body.Bytes(),
+			})
+			if err := client.Set(storeCtx, cacheKey, stored, cfg.TTL); err != nil {
+				logger.Warnf("Failed to store idempotent response: %v", err)
+			}
+		}
+
+		unlock()
+	}
+}
+
+// isUnsafeMethod reports whether the method may change server state
+func isUnsafeMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// idempotencyCacheKey scopes a client key to the user, method and path
+func idempotencyCacheKey(c *gin.Context, key string) string {
+	scope := "anonymous"
+	if userID, exists := c.Get("userID"); exists {
+		scope = fmt.Sprintf("user:%v", userID)
+	}
+
+	sum := sha256.Sum256([]byte(scope + "|" + c.Request.Method + "|" + c.Request.URL.Path + "|" + key))
+	return "idempotency:" + hex.EncodeToString(sum[:])
+}
+
+// responseRecorder copies the response body while writing it through
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+// Write implements http.ResponseWriter
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+// WriteString implements gin.ResponseWriter
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
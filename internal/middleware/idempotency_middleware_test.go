@@ -0,0 +1,116 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	"github.com/your-username/go-clean-architecture/config"
+	"github.com/your-username/go-clean-architecture/pkg/database"
+	"github.com/your-username/go-clean-architecture/pkg/logger"
+)
+
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+	logger.InitLogger(false)
+	os.Exit(m.Run())
+}
+
+// newTestRedis returns a client of a fresh miniredis server
+func newTestRedis(t *testing.T) (*database.RedisClient, *miniredis.Miniredis) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr(), MaxRetries: -1})
+	t.Cleanup(func() { _ = client.Close() })
+	return &database.RedisClient{Client: client}, server
+}
+
+// idempotentRouter serves POST /items behind the body limit and the
+// idempotency middleware, calling handler
+func idempotentRouter(client *database.RedisClient, maxBytes int64, handler gin.HandlerFunc) *gin.Engine {
+	router := gin.New()
+	router.Use(BodyLimitMiddleware(maxBytes))
+	router.Use(IdempotencyMiddleware(client, config.IdempotencyConfig{TTL: time.Hour, LockTTL: time.Minute}))
+	router.POST("/items", handler)
+	return router
+}
+
+func postItem(router http.Handler, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(idempotencyHeader, key)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestIdempotencyMiddlewareRejectsOversizedBody(t *testing.T) {
+	client, _ := newTestRedis(t)
+	router := idempotentRouter(client, 16, func(c *gin.Context) {
+		t.Error("handler called for an oversized body")
+	})
+
+	w := postItem(router, "key-1", `{"name":"a name longer than the limit"}`)
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusRequestEntityTooLarge)
+	}
+}
+
+func TestIdempotencyMiddlewareKeepsLockTakenAfterExpiry(t *testing.T) {
+	client, server := newTestRedis(t)
+	router := idempotentRouter(client, 1024, func(c *gin.Context) {
+		// The handler outlives the lock, which another request then claims
+		server.FastForward(2 * time.Minute)
+		for _, key := range server.Keys() {
+			if strings.HasPrefix(key, "lock:") {
+				t.Fatalf("lock %s still held after expiring", key)
+			}
+		}
+		if err := server.Set("lock:"+idempotencyCacheKey(c, "key-1"), "other-request"); err != nil {
+			t.Fatal(err)
+		}
+		c.JSON(http.StatusCreated, gin.H{"id": 1})
+	})
+
+	if w := postItem(router, "key-1", `{"name":"widget"}`); w.Code != http.StatusCreated {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusCreated)
+	}
+
+	var holders []string
+	for _, key := range server.Keys() {
+		if strings.HasPrefix(key, "lock:") {
+			holder, _ := server.Get(key)
+			holders = append(holders, holder)
+		}
+	}
+	if len(holders) != 1 || holders[0] != "other-request" {
+		t.Errorf("locks after the first request finished = %v, want the other request's", holders)
+	}
+}
+
+func TestIdempotencyMiddlewareReleasesLock(t *testing.T) {
+	client, server := newTestRedis(t)
+	router := idempotentRouter(client, 1024, func(c *gin.Context) {
+		c.JSON(http.StatusCreated, gin.H{"id": 1})
+	})
+
+	if w := postItem(router, "key-1", `{"name":"widget"}`); w.Code != http.StatusCreated {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusCreated)
+	}
+	for _, key := range server.Keys() {
+		if strings.HasPrefix(key, "lock:") {
+			t.Errorf("lock %s still held after the request finished", key)
+		}
+	}
+
+	w := postItem(router, "key-1", `{"name":"widget"}`)
+	if w.Code != http.StatusCreated || w.Header().Get(idempotencyReplayedHeader) != "true" {
+		t.Errorf("repeated request = %d, replayed %q; want the replayed 201", w.Code, w.Header().Get(idempotencyReplayedHeader))
+	}
+}
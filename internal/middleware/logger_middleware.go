@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"slices"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -8,8 +9,44 @@ import (
 	"github.com/your-username/go-clean-architecture/pkg/logger"
 )
 
-// LoggerMiddleware creates a logging middleware
-func LoggerMiddleware() gin.HandlerFunc {
+// Access log fields
+const (
+	AccessFieldStatusCode = "status_code"
+	AccessFieldLatency    = "latency"
+	AccessFieldClientIP   = "client_ip"
+	AccessFieldMethod     = "method"
+	AccessFieldPath       = "path"
+	AccessFieldUserID     = "user_id"
+	AccessFieldRole       = "role"
+	AccessFieldBytes      = "bytes"
+	AccessFieldUserAgent  = "user_agent"
+)
+
+// DefaultAccessLogFields are logged when no fields are configured
+var DefaultAccessLogFields = []string{
+	AccessFieldStatusCode,
+	AccessFieldLatency,
+	AccessFieldClientIP,
+	AccessFieldMethod,
+	AccessFieldPath,
+	AccessFieldUserID,
+	AccessFieldRole,
+	AccessFieldBytes,
+	AccessFieldUserAgent,
+}
+
+// accessLogFieldsKey stores a route's own access log fields
+const accessLogFieldsKey = "accessLogFields"
+
+// LoggerMiddleware creates a logging middleware that writes one access log
+// entry per request with the given fields, or DefaultAccessLogFields when
+// none are given. Routes may log a different set, see AccessLogFields.
+func LoggerMiddleware(fields []string) gin.HandlerFunc {
+	if len(fields) == 0 {
+		fields = DefaultAccessLogFields
+	}
+	warnUnknownAccessLogFields(fields)
+
 	return func(c *gin.Context) {
 		// Start timer
 		startTime := time.Now()
@@ -17,40 +54,81 @@ func LoggerMiddleware() gin.HandlerFunc {
 		// Process request
 		c.Next()
 
-		// Calculate latency
-		latency := time.Since(startTime)
+		// Read everything after c.Next so values set by later middleware,
+		// such as the authenticated user, are included
+		selected := fields
+		if routeFields, exists := c.Get(accessLogFieldsKey); exists {
+			selected = routeFields.([]string)
+		}
 
-		// Get status code
 		statusCode := c.Writer.Status()
+		entry := logger.WithFields(accessLogEntry(c, selected, time.Since(startTime)))
 
-		// Get client IP
-		clientIP := c.ClientIP()
+		// Errors attached by handlers are logged at a level matching the status
+		if len(c.Errors) > 0 {
+			entry = entry.WithField("errors", c.Errors.ByType(gin.ErrorTypePrivate).String())
+		}
 
-		// Get request method
-		method := c.Request.Method
+		if statusCode >= 500 {
+			entry.Error("Server error")
+		} else if statusCode >= 400 {
+			entry.Warn("Client error")
+		} else {
+			entry.Info("Request completed")
+		}
+	}
+}
 
-		// Get request path
-		path := c.Request.URL.Path
+// AccessLogFields creates a middleware that makes LoggerMiddleware log only
+// the given fields for the routes it is applied to, e.g. a reduced set for
+// high-traffic routes
+func AccessLogFields(fields ...string) gin.HandlerFunc {
+	warnUnknownAccessLogFields(fields)
 
-		// Log format
-		entry := logger.WithFields(logrus.Fields{
-			"status_code": statusCode,
-			"latency":     latency,
-			"client_ip":   clientIP,
-			"method":      method,
-			"path":        path,
-		})
+	return func(c *gin.Context) {
+		c.Set(accessLogFieldsKey, fields)
+		c.Next()
+	}
+}
 
-		if len(c.Errors) > 0 {
-			entry.Error(c.Errors.ByType(gin.ErrorTypePrivate).String())
-		} else {
-			if statusCode >= 500 {
-				entry.Error("Server error")
-			} else if statusCode >= 400 {
-				entry.Warn("Client error")
-			} else {
-				entry.Info("Request completed")
+// accessLogEntry builds the selected access log fields of a finished request.
+// The user fields are omitted for unauthenticated requests.
+func accessLogEntry(c *gin.Context, selected []string, latency time.Duration) logrus.Fields {
+	fields := make(logrus.Fields, len(selected))
+	for _, name := range selected {
+		switch name {
+		case AccessFieldStatusCode:
+			fields[name] = c.Writer.Status()
+		case AccessFieldLatency:
+			fields[name] = latency
+		case AccessFieldClientIP:
+			fields[name] = c.ClientIP()
+		case AccessFieldMethod:
+			fields[name] = c.Request.Method
+		case AccessFieldPath:
+			fields[name] = c.Request.URL.Path
+		case AccessFieldUserID:
+			if userID, exists := c.Get("userID"); exists {
+				fields[name] = userID
+			}
+		case AccessFieldRole:
+			if role, exists := c.Get("userRole"); exists {
+				fields[name] = role
 			}
+		case AccessFieldBytes:
+			fields[name] = max(c.Writer.Size(), 0)
+		case AccessFieldUserAgent:
+			fields[name] = c.Request.UserAgent()
+		}
+	}
+	return fields
+}
+
+// warnUnknownAccessLogFields logs configured fields that are never written
+func warnUnknownAccessLogFields(fields []string) {
+	for _, name := range fields {
+		if !slices.Contains(DefaultAccessLogFields, name) {
+			logger.Warnf("Unknown access log field %q ignored", name)
 		}
 	}
 }
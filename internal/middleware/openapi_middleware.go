@@ -0,0 +1,150 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi2"
+	"github.com/getkin/kin-openapi/openapi2conv"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers/legacy"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/your-username/go-clean-architecture/config"
+	"github.com/your-username/go-clean-architecture/pkg/apperrors"
+	"github.com/your-username/go-clean-architecture/pkg/i18n"
+	"github.com/your-username/go-clean-architecture/pkg/logger"
+	"github.com/your-username/go-clean-architecture/pkg/response"
+)
+
+// openAPIMaxResponseBytes bounds the response body kept for validation.
+// Larger bodies only have their status and headers checked.
+const openAPIMaxResponseBytes = 1 << 20
+
+// OpenAPIMiddleware creates a middleware that validates requests and
+// responses against swaggerDoc, a Swagger 2.0 document such as the one
+// generated into docs. Documented paths are relative to basePath, and
+// requests under skipPrefixes or for undocumented routes are not checked.
+// Mismatches are logged; in reject mode, a nonconformant request is also
+// answered with a 400 before reaching its handler. Responses are only
+// logged, as the client cannot fix them. Validation buffers request bodies,
+// so the middleware is meant for debug mode only.
+func OpenAPIMiddleware(swaggerDoc, basePath, mode string, skipPrefixes ...string) (gin.HandlerFunc, error) {
+	var doc2 openapi2.T
+	if err := json.Unmarshal([]byte(swaggerDoc), &doc2); err != nil {
+		return nil, fmt.Errorf("failed to parse swagger document: %w", err)
+	}
+	doc, err := openapi2conv.ToV3(&doc2)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert swagger document: %w", err)
+	}
+	// Routes are matched on the path with the base path stripped, whatever
+	// host the document was generated for
+	doc.Servers = nil
+
+	router, err := legacy.NewRouter(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load swagger document: %w", err)
+	}
+
+	reject := mode == config.OpenAPIValidationReject
+	options := &openapi3filter.Options{
+		MultiError: true,
+		// Authentication is enforced by AuthMiddleware, not by the document
+		AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+	}
+	// The default message repeats the whole schema and value for each error
+	options.WithCustomSchemaErrorFunc(func(err *openapi3.SchemaError) string {
+		if pointer := err.JSONPointer(); len(pointer) > 0 {
+			return fmt.Sprintf("/%s: %s", strings.Join(pointer, "/"), err.Reason)
+		}
+		return err.Reason
+	})
+
+	return func(c *gin.Context) {
+		path := strings.TrimPrefix(c.Request.URL.Path, basePath)
+		for _, prefix := range skipPrefixes {
+			if strings.HasPrefix(path, prefix) {
+				c.Next()
+				return
+			}
+		}
+
+		// The router sees the documented path; the clone shares the body
+		req := c.Request.Clone(c.Request.Context())
+		req.URL.Path = path
+		route, pathParams, err := router.FindRoute(req)
+		if err != nil {
+			c.Next()
+			return
+		}
+
+		log := logger.WithContext(c.Request.Context()).WithFields(logrus.Fields{
+			"method": c.Request.Method,
+			"route":  route.Method + " " + route.Path,
+		})
+
+		input := &openapi3filter.RequestValidationInput{
+			Request:    req,
+			PathParams: pathParams,
+			Route:      route,
+			Options:    options,
+		}
+		err = openapi3filter.ValidateRequest(c.Request.Context(), input)
+		// Validation reads the body, then hands it back on the clone
+		c.Request.Body = req.Body
+		if err != nil {
+			log.WithField("error", err).Warn("Request does not match the API documentation")
+			if reject {
+				rejectRequest(c, err)
+				return
+			}
+		}
+
+		writer := &capturingWriter{ResponseWriter: c.Writer, capture: &bodyCapture{limit: openAPIMaxResponseBytes}}
+		c.Writer = writer
+
+		c.Next()
+		c.Writer = writer.ResponseWriter
+
+		validateResponse(c.Request.Context(), log, input, writer)
+	}, nil
+}
+
+// rejectRequest answers a request that failed validation. A body over the
+// size limit is reported as such rather than as a documentation mismatch.
+func rejectRequest(c *gin.Context, err error) {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		response.FromError(c, apperrors.ErrPayloadTooLarge)
+	} else {
+		response.Error(c, http.StatusBadRequest, i18n.MsgRequestUndocumented, err.Error())
+	}
+	c.Abort()
+}
+
+// validateResponse logs a response that does not match its documentation
+func validateResponse(ctx context.Context, log *logrus.Entry, request *openapi3filter.RequestValidationInput, writer *capturingWriter) {
+	options := *request.Options
+	options.ExcludeResponseBody = writer.capture.truncated
+
+	input := &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: request,
+		Status:                 writer.Status(),
+		Header:                 writer.Header(),
+		Options:                &options,
+	}
+	input.SetBodyBytes(writer.capture.buf.Bytes())
+
+	if err := openapi3filter.ValidateResponse(ctx, input); err != nil {
+		log.WithFields(logrus.Fields{
+			"status_code": writer.Status(),
+			"error":       err,
+		}).Warn("Response does not match the API documentation")
+	}
+}
@@ -1,23 +1,92 @@
 package middleware
 
 import (
+	"fmt"
 	"net/http"
+	"runtime"
+	"runtime/debug"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/your-username/go-clean-architecture/pkg/apperrors"
 	"github.com/your-username/go-clean-architecture/pkg/logger"
+	"github.com/your-username/go-clean-architecture/pkg/metrics"
 	"github.com/your-username/go-clean-architecture/pkg/response"
 )
 
-// RecoveryMiddleware creates a recovery middleware that handles panics
-func RecoveryMiddleware() gin.HandlerFunc {
+// RecoveryMiddleware creates a recovery middleware that handles panics. The
+// response carries a reference the client can quote to support: the request
+// ID when there is one, otherwise a generated ID. The same reference is
+// logged with the stack, and in debug mode a truncated stack is included in
+// the response.
+func RecoveryMiddleware(debugMode bool) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		defer func() {
-			if err := recover(); err != nil {
-				logger.Errorf("Panic recovered: %v", err)
-				response.Error(c, http.StatusInternalServerError, "Internal server error", nil)
-				c.Abort()
+			err := recover()
+			if err == nil {
+				return
 			}
+			// The server closes the connection silently for this one
+			if err == http.ErrAbortHandler {
+				panic(err)
+			}
+
+			metrics.PanicsRecoveredTotal.Inc()
+
+			reference := logger.RequestIDFromContext(c.Request.Context())
+			if reference == "" {
+				reference = c.GetHeader(requestIDHeader)
+			}
+			if reference == "" {
+				reference = uuid.NewString()
+			}
+
+			logger.WithContext(c.Request.Context()).WithFields(logrus.Fields{
+				"reference": reference,
+				"path":      c.Request.URL.Path,
+				"stack":     string(debug.Stack()),
+			}).Errorf("Panic recovered: %v", err)
+
+			// Part of another response was already sent, so a 500 can no
+			// longer be written. Aborting the handler makes the server close
+			// the connection instead of leaving the client waiting.
+			if c.Writer.Written() {
+				panic(http.ErrAbortHandler)
+			}
+
+			body := gin.H{"reference": reference}
+			if debugMode {
+				body["detail"] = fmt.Sprint(err)
+				body["stack"] = panicFrames(debugStackFrames)
+			}
+			c.Header(requestIDHeader, reference)
+			response.ErrorWithCode(c, http.StatusInternalServerError, apperrors.SlugInternalServer, "Internal server error", body)
+			c.Abort()
 		}()
 		c.Next()
 	}
 }
+
+// panicFrames returns up to limit frames of the panicking goroutine, starting
+// at the function that panicked. It must be called from the deferred function
+// that recovered.
+func panicFrames(limit int) []string {
+	pcs := make([]uintptr, 64)
+	iter := runtime.CallersFrames(pcs[:runtime.Callers(1, pcs)])
+
+	lines := make([]string, 0, limit)
+	panicking := false
+	for len(lines) < limit {
+		frame, more := iter.Next()
+		if panicking {
+			lines = append(lines, fmt.Sprintf("%s %s:%d", frame.Function, frame.File, frame.Line))
+		} else if frame.Function == "runtime.gopanic" {
+			panicking = true
+		}
+		if !more {
+			break
+		}
+	}
+	return lines
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/your-username/go-clean-architecture/internal/entity"
+	"github.com/your-username/go-clean-architecture/pkg/pagination"
+)
+
+// AuditLogFilter narrows audit log queries. Zero values are ignored.
+type AuditLogFilter struct {
+	ActorID *uint
+	Action  string
+}
+
+// AuditLogRepository defines the audit log repository interface
+type AuditLogRepository interface {
+	Create(ctx context.Context, log *entity.AuditLog) error
+	FindAll(ctx context.Context, filter AuditLogFilter, page, limit int, mode pagination.TotalMode) ([]entity.AuditLog, int64, error)
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/your-username/go-clean-architecture/internal/entity"
+	"github.com/your-username/go-clean-architecture/pkg/apperrors"
+	"github.com/your-username/go-clean-architecture/pkg/database"
+	"github.com/your-username/go-clean-architecture/pkg/pagination"
+	"gorm.io/gorm"
+)
+
+type auditLogRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditLogRepository creates a new audit log repository
+func NewAuditLogRepository(db *gorm.DB) AuditLogRepository {
+	return &auditLogRepository{db: db}
+}
+
+// Create creates a new audit log entry
+func (r *auditLogRepository) Create(ctx context.Context, log *entity.AuditLog) error {
+	return queryError(ctx, r.db.WithContext(ctx).Create(log).Error, apperrors.ErrNotFound)
+}
+
+// FindAll finds audit log entries matching the filter, newest first, with
+// pagination, counting them as mode asks
+func (r *auditLogRepository) FindAll(ctx context.Context, filter AuditLogFilter, page, limit int, mode pagination.TotalMode) ([]entity.AuditLog, int64, error) {
+	var logs []entity.AuditLog
+	query := r.db.WithContext(ctx).Model(&entity.AuditLog{}).
+		Scopes(filterAuditLogs(filter)).
+		Order("created_at DESC, id DESC")
+	total, err := database.PaginateWithTotal(query, page, limit, mode, &logs)
+	if err != nil {
+		return nil, 0, queryError(ctx, err, apperrors.ErrNotFound)
+	}
+
+	return logs, total, nil
+}
+
+// filterAuditLogs returns a scope applying the audit log filter
+func filterAuditLogs(filter AuditLogFilter) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if filter.ActorID != nil {
+			db = db.Where("actor_id = ?", *filter.ActorID)
+		}
+		if filter.Action != "" {
+			db = db.Where("action = ?", filter.Action)
+		}
+		return db
+	}
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/your-username/go-clean-architecture/pkg/apperrors"
+	"github.com/your-username/go-clean-architecture/pkg/database"
+	"github.com/your-username/go-clean-architecture/pkg/pagination"
+	"gorm.io/gorm"
+)
+
+// BaseRepository implements the CRUD operations shared by entity
+// repositories. Entity repositories embed it and add their own queries.
+// Every call is bounded by the query timeout, see withQueryTimeout, and
+// errors are translated by queryError.
+type BaseRepository[T any] struct {
+	db           *gorm.DB
+	queryTimeout time.Duration
+	notFound     *apperrors.AppError
+}
+
+// NewBaseRepository creates a new base repository for T. notFound is returned,
+// wrapped, when a record does not exist.
+func NewBaseRepository[T any](db *gorm.DB, queryTimeout time.Duration, notFound *apperrors.AppError) *BaseRepository[T] {
+	return &BaseRepository[T]{db: db, queryTimeout: queryTimeout, notFound: notFound}
+}
+
+// dbError translates err with this repository's not-found error
+func (r *BaseRepository[T]) dbError(ctx context.Context, err error) error {
+	return queryError(ctx, err, r.notFound)
+}
+
+// Create creates a new record
+func (r *BaseRepository[T]) Create(ctx context.Context, entity *T) error {
+	ctx, cancel := withQueryTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	return r.dbError(ctx, r.db.WithContext(ctx).Create(entity).Error)
+}
+
+// FindByID finds a record by primary key
+func (r *BaseRepository[T]) FindByID(ctx context.Context, id uint) (*T, error) {
+	ctx, cancel := withQueryTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	var entity T
+	if err := r.db.WithContext(ctx).First(&entity, id).Error; err != nil {
+		return nil, r.dbError(ctx, err)
+	}
+	return &entity, nil
+}
+
+// FindAll finds one page of records matching the scopes and counts all
+// matching records as mode asks, see database.PaginateWithTotal
+func (r *BaseRepository[T]) FindAll(ctx context.Context, page, limit int, mode pagination.TotalMode, scopes ...func(*gorm.DB) *gorm.DB) ([]T, int64, error) {
+	ctx, cancel := withQueryTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	var entities []T
+	total, err := database.PaginateWithTotal(r.db.WithContext(ctx).Model(new(T)).Scopes(scopes...), page, limit, mode, &entities)
+	if err != nil {
+		return nil, 0, r.dbError(ctx, err)
+	}
+
+	return entities, total, nil
+}
+
+// Update saves all fields of a record
+func (r *BaseRepository[T]) Update(ctx context.Context, entity *T) error {
+	ctx, cancel := withQueryTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	return r.dbError(ctx, r.db.WithContext(ctx).Save(entity).Error)
+}
+
+// Delete deletes a record by primary key, softly if T supports it
+func (r *BaseRepository[T]) Delete(ctx context.Context, id uint) error {
+	ctx, cancel := withQueryTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	return r.dbError(ctx, r.db.WithContext(ctx).Delete(new(T), id).Error)
+}
+
+// Count counts records matching the scopes
+func (r *BaseRepository[T]) Count(ctx context.Context, scopes ...func(*gorm.DB) *gorm.DB) (int64, error) {
+	ctx, cancel := withQueryTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	var total int64
+	if err := r.db.WithContext(ctx).Model(new(T)).Scopes(scopes...).Count(&total).Error; err != nil {
+		return 0, r.dbError(ctx, err)
+	}
+	return total, nil
+}
@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/your-username/go-clean-architecture/pkg/apperrors"
+	"github.com/your-username/go-clean-architecture/pkg/database/dbtest"
+	"github.com/your-username/go-clean-architecture/pkg/pagination"
+	"gorm.io/gorm"
+)
+
+// widget is a soft-deletable model for BaseRepository tests
+type widget struct {
+	ID        uint
+	Name      string
+	Color     string
+	DeletedAt gorm.DeletedAt
+}
+
+var errWidgetNotFound = &apperrors.AppError{Code: http.StatusNotFound, Slug: "WIDGET_NOT_FOUND", Message: "Widget not found"}
+
+// newWidgetRepository returns a repository over a fresh database holding the
+// given widgets
+func newWidgetRepository(t *testing.T, widgets ...widget) (*BaseRepository[widget], *gorm.DB) {
+	t.Helper()
+	db := dbtest.New(t, &widget{})
+	for i := range widgets {
+		if err := db.Create(&widgets[i]).Error; err != nil {
+			t.Fatalf("failed to seed widget %s: %v", widgets[i].Name, err)
+		}
+	}
+	return NewBaseRepository[widget](db, time.Second, errWidgetNotFound), db
+}
+
+// colored scopes a query to widgets of one color
+func colored(color string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("color = ?", color)
+	}
+}
+
+func TestBaseRepositoryCreateAndFindByID(t *testing.T) {
+	repo, _ := newWidgetRepository(t)
+	ctx := context.Background()
+
+	w := widget{Name: "bolt", Color: "red"}
+	if err := repo.Create(ctx, &w); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if w.ID == 0 {
+		t.Fatal("Create() did not set the ID")
+	}
+
+	found, err := repo.FindByID(ctx, w.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if found.Name != "bolt" || found.Color != "red" {
+		t.Errorf("FindByID() = %+v, want %+v", found, w)
+	}
+
+	if _, err := repo.FindByID(ctx, w.ID+1); !errors.Is(err, errWidgetNotFound) {
+		t.Errorf("FindByID() of a missing ID error = %v, want the not-found error", err)
+	}
+}
+
+func TestBaseRepositoryUpdate(t *testing.T) {
+	repo, _ := newWidgetRepository(t, widget{Name: "bolt", Color: "red"})
+	ctx := context.Background()
+
+	w, err := repo.FindByID(ctx, 1)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	w.Color = "blue"
+	if err := repo.Update(ctx, w); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	if updated, _ := repo.FindByID(ctx, 1); updated == nil || updated.Color != "blue" {
+		t.Errorf("FindByID() after Update() = %+v, want color blue", updated)
+	}
+}
+
+func TestBaseRepositoryDeleteIsSoft(t *testing.T) {
+	repo, db := newWidgetRepository(t, widget{Name: "bolt", Color: "red"}, widget{Name: "nut", Color: "red"})
+	ctx := context.Background()
+
+	if err := repo.Delete(ctx, 1); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := repo.FindByID(ctx, 1); !errors.Is(err, errWidgetNotFound) {
+		t.Errorf("FindByID() after Delete() error = %v, want the not-found error", err)
+	}
+	if total, err := repo.Count(ctx); err != nil || total != 1 {
+		t.Errorf("Count() after Delete() = %d, %v; want 1", total, err)
+	}
+
+	var kept int64
+	db.Unscoped().Model(&widget{}).Where("id = ?", 1).Count(&kept)
+	if kept != 1 {
+		t.Error("Delete() removed the row instead of marking it deleted")
+	}
+}
+
+func TestBaseRepositoryFindAll(t *testing.T) {
+	repo, _ := newWidgetRepository(t,
+		widget{Name: "a", Color: "red"},
+		widget{Name: "b", Color: "blue"},
+		widget{Name: "c", Color: "red"},
+		widget{Name: "d", Color: "red"},
+		widget{Name: "e", Color: "red"},
+	)
+
+	tests := []struct {
+		name      string
+		page      int
+		limit     int
+		mode      pagination.TotalMode
+		scopes    []func(*gorm.DB) *gorm.DB
+		wantNames []string
+		wantTotal int64
+	}{
+		{name: "first page", page: 1, limit: 2, mode: pagination.TotalExact, wantNames: []string{"a", "b"}, wantTotal: 5},
+		{name: "last partial page", page: 3, limit: 2, mode: pagination.TotalExact, wantNames: []string{"e"}, wantTotal: 5},
+		{name: "past the end", page: 4, limit: 2, mode: pagination.TotalExact, wantTotal: 5},
+		{name: "scoped", page: 1, limit: 10, mode: pagination.TotalExact, scopes: []func(*gorm.DB) *gorm.DB{colored("red")}, wantNames: []string{"a", "c", "d", "e"}, wantTotal: 4},
+		{name: "scoped second page", page: 2, limit: 3, mode: pagination.TotalExact, scopes: []func(*gorm.DB) *gorm.DB{colored("red")}, wantNames: []string{"e"}, wantTotal: 4},
+		{name: "estimated total counts exactly on SQLite", page: 1, limit: 2, mode: pagination.TotalEstimated, wantNames: []string{"a", "b"}, wantTotal: 5},
+		{name: "omitted total", page: 1, limit: 2, mode: pagination.TotalOmitted, wantNames: []string{"a", "b"}, wantTotal: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			widgets, total, err := repo.FindAll(context.Background(), tt.page, tt.limit, tt.mode, tt.scopes...)
+			if err != nil {
+				t.Fatalf("FindAll() error = %v", err)
+			}
+			if total != tt.wantTotal {
+				t.Errorf("FindAll() total = %d, want %d", total, tt.wantTotal)
+			}
+			if len(widgets) != len(tt.wantNames) {
+				t.Fatalf("FindAll() returned %d widgets, want %v", len(widgets), tt.wantNames)
+			}
+			for i, w := range widgets {
+				if w.Name != tt.wantNames[i] {
+					t.Errorf("FindAll()[%d] = %s, want %s", i, w.Name, tt.wantNames[i])
+				}
+			}
+		})
+	}
+}
+
+func TestBaseRepositoryCount(t *testing.T) {
+	repo, _ := newWidgetRepository(t, widget{Name: "a", Color: "red"}, widget{Name: "b", Color: "blue"}, widget{Name: "c", Color: "red"})
+	ctx := context.Background()
+
+	if total, err := repo.Count(ctx); err != nil || total != 3 {
+		t.Errorf("Count() = %d, %v; want 3", total, err)
+	}
+	if total, err := repo.Count(ctx, colored("red")); err != nil || total != 2 {
+		t.Errorf("Count(red) = %d, %v; want 2", total, err)
+	}
+}
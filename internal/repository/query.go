@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/your-username/go-clean-architecture/pkg/apperrors"
+	"gorm.io/gorm"
+)
+
+// Query errors, wrapped in the AppError a repository returns so callers can
+// still tell them apart with errors.Is
+var (
+	// ErrQueryTimeout is returned when a query exceeds its deadline
+	ErrQueryTimeout = errors.New("query timed out")
+	// ErrQueryCanceled is returned when the caller cancels a running query
+	ErrQueryCanceled = errors.New("query canceled")
+)
+
+// withQueryTimeout bounds ctx by timeout unless it already has a deadline or
+// timeout is not positive
+func withQueryTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// queryError translates a GORM error into an AppError so callers do not
+// depend on GORM: missing records become notFound, queries ended by ctx
+// become ErrQueryTimeout, and anything else an internal error. Drivers report
+// context errors inconsistently, so the context itself is checked as well.
+func queryError(ctx context.Context, err error, notFound *apperrors.AppError) error {
+	if err == nil {
+		return nil
+	}
+
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return apperrors.WrapError(notFound, err)
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return apperrors.WrapError(apperrors.ErrQueryTimeout, fmt.Errorf("%w: %v", ErrQueryTimeout, err))
+	case errors.Is(err, context.Canceled), errors.Is(ctx.Err(), context.Canceled):
+		return apperrors.WrapError(apperrors.ErrInternalServer, fmt.Errorf("%w: %v", ErrQueryCanceled, err))
+	default:
+		return apperrors.WrapError(apperrors.ErrInternalServer, err)
+	}
+}
+
+// isUniqueViolation reports whether err is a unique constraint violation.
+// Postgres errors are recognized by their SQLSTATE through an interface, so
+// both pgx and lib/pq work, and SQLite errors by their message.
+func isUniqueViolation(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return true
+	}
+	var coded interface{ SQLState() string }
+	if errors.As(err, &coded) {
+		return coded.SQLState() == "23505"
+	}
+	return strings.Contains(err.Error(), "UNIQUE constraint failed")
+}
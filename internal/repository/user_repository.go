@@ -2,16 +2,43 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/your-username/go-clean-architecture/internal/entity"
+	"github.com/your-username/go-clean-architecture/pkg/pagination"
 )
 
+// UserFilter narrows user queries. Zero values are ignored.
+type UserFilter struct {
+	Role   string
+	Status string
+	// IsActive matches the active status when true and any other when false
+	IsActive *bool
+	// Search matches name or email, case-insensitively
+	Search string
+	// IncludeDeleted also matches soft-deleted users
+	IncludeDeleted bool
+}
+
 // UserRepository defines the user repository interface
 type UserRepository interface {
 	Create(ctx context.Context, user *entity.User) error
 	FindByID(ctx context.Context, id uint) (*entity.User, error)
+	FindByIDWithDeleted(ctx context.Context, id uint) (*entity.User, error)
+	FindByIDs(ctx context.Context, ids []uint) ([]entity.User, error)
 	FindByEmail(ctx context.Context, email string) (*entity.User, error)
-	FindAll(ctx context.Context, page, limit int) ([]entity.User, int64, error)
+	FindByEmailWithDeleted(ctx context.Context, email string) (*entity.User, error)
+	ExistsByEmail(ctx context.Context, email string) (bool, error)
+	FindAll(ctx context.Context, filter UserFilter, page, limit int, mode pagination.TotalMode) ([]entity.User, int64, error)
+	CountByFilter(ctx context.Context, filter UserFilter) (int64, error)
+	Search(ctx context.Context, filter UserFilter, page, limit int) ([]entity.User, int64, error)
+	FindInBatches(ctx context.Context, filter UserFilter, batchSize int, fn func([]entity.User) error) error
 	Update(ctx context.Context, user *entity.User) error
+	UpdatePartial(ctx context.Context, id uint, fields map[string]interface{}) error
 	Delete(ctx context.Context, id uint) error
+	DeleteByIDs(ctx context.Context, ids []uint) ([]uint, error)
+	Restore(ctx context.Context, id uint) error
+	PurgeByID(ctx context.Context, id uint) error
+	PurgeDeletedBefore(ctx context.Context, cutoff time.Time) (int64, error)
+	IncrementTokenVersion(ctx context.Context, id uint) error
 }
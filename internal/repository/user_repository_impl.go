@@ -2,67 +2,429 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"strings"
+	"time"
 
 	"github.com/your-username/go-clean-architecture/internal/entity"
+	"github.com/your-username/go-clean-architecture/pkg/apperrors"
+	"github.com/your-username/go-clean-architecture/pkg/constants"
+	"github.com/your-username/go-clean-architecture/pkg/database"
+	"github.com/your-username/go-clean-architecture/pkg/pagination"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// userRepository gets FindByID and Count from the embedded BaseRepository
 type userRepository struct {
-	db *gorm.DB
+	*BaseRepository[entity.User]
+	tx database.Transactor
+	// hardDelete removes the rows of deleted users instead of soft-deleting them
+	hardDelete bool
 }
 
-// NewUserRepository creates a new user repository
-func NewUserRepository(db *gorm.DB) UserRepository {
-	return &userRepository{db: db}
+// NewUserRepository creates a new user repository. Transactions run through
+// tx, which retries them on transient errors. Each call is bounded by
+// queryTimeout unless the caller's context already has a deadline; zero
+// disables the timeout. With hardDelete, Delete and DeleteByIDs remove rows
+// rather than soft-deleting them.
+func NewUserRepository(db *gorm.DB, tx database.Transactor, queryTimeout time.Duration, hardDelete bool) UserRepository {
+	return &userRepository{
+		BaseRepository: NewBaseRepository[entity.User](db, queryTimeout, apperrors.ErrUserNotFound),
+		tx:             tx,
+		hardDelete:     hardDelete,
+	}
 }
 
-// Create creates a new user
+// Create creates a new user. It returns apperrors.ErrEmailTaken if another
+// user that is not deleted already has the email.
 func (r *userRepository) Create(ctx context.Context, user *entity.User) error {
-	return r.db.WithContext(ctx).Create(user).Error
+	ctx, cancel := withQueryTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	return r.userError(ctx, r.db.WithContext(ctx).Create(user).Error)
 }
 
-// FindByID finds a user by ID
-func (r *userRepository) FindByID(ctx context.Context, id uint) (*entity.User, error) {
+// FindByIDWithDeleted finds a user by ID, including soft-deleted users
+func (r *userRepository) FindByIDWithDeleted(ctx context.Context, id uint) (*entity.User, error) {
+	ctx, cancel := withQueryTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
 	var user entity.User
-	if err := r.db.WithContext(ctx).First(&user, id).Error; err != nil {
-		return nil, err
+	if err := r.db.WithContext(ctx).Unscoped().First(&user, id).Error; err != nil {
+		return nil, r.dbError(ctx, err)
 	}
 	return &user, nil
 }
 
-// FindByEmail finds a user by email
+// FindByIDs finds the users with the given IDs in a single query. Missing IDs
+// are skipped and the order of the result is unspecified.
+func (r *userRepository) FindByIDs(ctx context.Context, ids []uint) ([]entity.User, error) {
+	if len(ids) == 0 {
+		return []entity.User{}, nil
+	}
+
+	ctx, cancel := withQueryTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	var users []entity.User
+	if err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&users).Error; err != nil {
+		return nil, r.dbError(ctx, err)
+	}
+	return users, nil
+}
+
+// FindByEmail finds a user by email. Soft-deleted users are ignored, so their
+// email can be registered again.
 func (r *userRepository) FindByEmail(ctx context.Context, email string) (*entity.User, error) {
+	ctx, cancel := withQueryTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
 	var user entity.User
 	if err := r.db.WithContext(ctx).Where("email = ?", email).First(&user).Error; err != nil {
-		return nil, err
+		return nil, r.dbError(ctx, err)
+	}
+	return &user, nil
+}
+
+// ExistsByEmail reports whether a user that is not deleted has the email
+func (r *userRepository) ExistsByEmail(ctx context.Context, email string) (bool, error) {
+	ctx, cancel := withQueryTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&entity.User{}).Where("email = ?", email).Limit(1).Count(&count).Error; err != nil {
+		return false, r.dbError(ctx, err)
+	}
+	return count > 0, nil
+}
+
+// FindByEmailWithDeleted finds a user by email, including soft-deleted users.
+// Several users may share a deleted email, so the user that is not deleted
+// comes first, then the most recently deleted one.
+func (r *userRepository) FindByEmailWithDeleted(ctx context.Context, email string) (*entity.User, error) {
+	ctx, cancel := withQueryTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	var user entity.User
+	err := r.db.WithContext(ctx).Unscoped().
+		Where("email = ?", email).
+		Order("deleted_at IS NULL DESC").
+		Order("deleted_at DESC").
+		First(&user).Error
+	if err != nil {
+		return nil, r.dbError(ctx, err)
 	}
 	return &user, nil
 }
 
-// FindAll finds all users with pagination
-func (r *userRepository) FindAll(ctx context.Context, page, limit int) ([]entity.User, int64, error) {
+// FindAll finds all users matching the filter with pagination, counting them
+// as mode asks
+func (r *userRepository) FindAll(ctx context.Context, filter UserFilter, page, limit int, mode pagination.TotalMode) ([]entity.User, int64, error) {
+	return r.BaseRepository.FindAll(ctx, page, limit, mode, filterUsers(filter))
+}
+
+// CountByFilter counts the users matching the filter
+func (r *userRepository) CountByFilter(ctx context.Context, filter UserFilter) (int64, error) {
+	return r.BaseRepository.Count(ctx, filterUsers(filter))
+}
+
+// Search finds users whose name or email contains query, case-insensitively,
+// with pagination. Results are ranked by relevance: an exact email match
+// first, then users whose email or name starts with query, then the rest.
+func (r *userRepository) Search(ctx context.Context, filter UserFilter, page, limit int) ([]entity.User, int64, error) {
+	return r.BaseRepository.FindAll(ctx, page, limit, pagination.TotalExact, filterUsers(filter), rankUserSearch(filter.Search))
+}
+
+// FindInBatches calls fn with successive batches of users matching the filter,
+// ordered by ID, so large result sets never have to be held in memory at once.
+// The query timeout is not applied here since the whole iteration, including
+// fn, would have to fit in it; callers bound it through ctx instead.
+func (r *userRepository) FindInBatches(ctx context.Context, filter UserFilter, batchSize int, fn func([]entity.User) error) error {
 	var users []entity.User
-	var total int64
+	err := r.db.WithContext(ctx).
+		Scopes(filterUsers(filter)).
+		FindInBatches(&users, batchSize, func(tx *gorm.DB, batch int) error {
+			return fn(users)
+		}).Error
+	return r.dbError(ctx, err)
+}
 
-	offset := (page - 1) * limit
+// Update saves all fields of a user except the token version, which is only
+// changed by IncrementTokenVersion so a concurrent save cannot undo it. The
+// save only applies if the row still has user.Version, which is then
+// incremented; otherwise apperrors.ErrVersionConflict is returned.
+func (r *userRepository) Update(ctx context.Context, user *entity.User) error {
+	ctx, cancel := withQueryTimeout(ctx, r.queryTimeout)
+	defer cancel()
 
-	if err := r.db.WithContext(ctx).Model(&entity.User{}).Count(&total).Error; err != nil {
-		return nil, 0, err
+	expected := user.Version
+	user.Version++
+
+	// Updates rather than Save, which would insert the row when the version
+	// check matches nothing
+	result := r.db.WithContext(ctx).
+		Model(user).
+		Where("version = ?", expected).
+		Select("*").
+		Omit("id", "token_version").
+		Updates(user)
+	if result.Error != nil {
+		user.Version = expected
+		return r.userError(ctx, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		user.Version = expected
+		return apperrors.ErrVersionConflict
 	}
+	return nil
+}
+
+// UpdatePartial writes only the given columns of a user, so a column can be
+// set to its zero value and absent columns are left untouched. The id and
+// token version columns are never written. A "version" key is the version the
+// caller read: the update only applies if the row still has it, and
+// apperrors.ErrVersionConflict is returned otherwise. The version is
+// incremented by every partial update.
+func (r *userRepository) UpdatePartial(ctx context.Context, id uint, fields map[string]interface{}) error {
+	ctx, cancel := withQueryTimeout(ctx, r.queryTimeout)
+	defer cancel()
 
-	if err := r.db.WithContext(ctx).Offset(offset).Limit(limit).Find(&users).Error; err != nil {
-		return nil, 0, err
+	updates := make(map[string]interface{}, len(fields)+1)
+	for column, value := range fields {
+		updates[column] = value
 	}
+	expected, checkVersion := updates["version"]
+	updates["version"] = gorm.Expr("version + 1")
 
-	return users, total, nil
+	query := r.db.WithContext(ctx).
+		Model(&entity.User{}).
+		Where("id = ?", id)
+	if checkVersion {
+		query = query.Where("version = ?", expected)
+	}
+	result := query.Omit("id", "token_version").Updates(updates)
+	if result.Error != nil {
+		return r.userError(ctx, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		if checkVersion {
+			return apperrors.ErrVersionConflict
+		}
+		return apperrors.ErrUserNotFound
+	}
+	return nil
 }
 
-// Update updates a user
-func (r *userRepository) Update(ctx context.Context, user *entity.User) error {
-	return r.db.WithContext(ctx).Save(user).Error
+// IncrementTokenVersion bumps the user's token version, revoking every token
+// issued before. It returns apperrors.ErrUserNotFound if the user does not
+// exist.
+func (r *userRepository) IncrementTokenVersion(ctx context.Context, id uint) error {
+	ctx, cancel := withQueryTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	result := r.db.WithContext(ctx).
+		Model(&entity.User{}).
+		Where("id = ?", id).
+		Update("token_version", gorm.Expr("token_version + 1"))
+	if result.Error != nil {
+		return r.dbError(ctx, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.ErrUserNotFound
+	}
+	return nil
 }
 
-// Delete deletes a user (soft delete)
+// Delete deletes a user, removing the row in hard delete mode
 func (r *userRepository) Delete(ctx context.Context, id uint) error {
-	return r.db.WithContext(ctx).Delete(&entity.User{}, id).Error
+	ctx, cancel := withQueryTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	return r.dbError(ctx, r.deleteScope(r.db.WithContext(ctx)).Delete(&entity.User{}, id).Error)
+}
+
+// deleteScope applies the delete mode to db
+func (r *userRepository) deleteScope(db *gorm.DB) *gorm.DB {
+	if r.hardDelete {
+		return db.Unscoped()
+	}
+	return db
+}
+
+// DeleteByIDs deletes the users with the given IDs in one transaction, as
+// Delete does, and returns the IDs of the users deleted. IDs of users that do
+// not exist or were already deleted are left out. It returns
+// apperrors.ErrLastAdmin, deleting nothing, when the batch includes every
+// remaining active admin. The active admins are locked while counting, so
+// concurrent batches cannot each leave the other to delete the last one.
+func (r *userRepository) DeleteByIDs(ctx context.Context, ids []uint) ([]uint, error) {
+	if len(ids) == 0 {
+		return []uint{}, nil
+	}
+
+	ctx, cancel := withQueryTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	var deleted []uint
+	err := r.tx.Transaction(ctx, func(tx *gorm.DB) error {
+		var admins []uint
+		if err := tx.Model(&entity.User{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("role = ? AND status = ?", constants.RoleAdmin, constants.UserStatusActive).
+			Pluck("id", &admins).Error; err != nil {
+			return err
+		}
+
+		deleted = nil
+		if err := tx.Model(&entity.User{}).Where("id IN ?", ids).Pluck("id", &deleted).Error; err != nil {
+			return err
+		}
+		if len(deleted) == 0 {
+			return nil
+		}
+
+		if lastAdminDeleted(admins, deleted) {
+			return apperrors.ErrLastAdmin
+		}
+		return r.deleteScope(tx).Where("id IN ?", deleted).Delete(&entity.User{}).Error
+	})
+	if errors.Is(err, apperrors.ErrLastAdmin) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, r.dbError(ctx, err)
+	}
+	return deleted, nil
+}
+
+// lastAdminDeleted reports whether deleting ids removes active admins and
+// leaves none of admins
+func lastAdminDeleted(admins, ids []uint) bool {
+	deleting := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		deleting[id] = true
+	}
+
+	remaining := len(admins)
+	for _, id := range admins {
+		if deleting[id] {
+			remaining--
+		}
+	}
+	return remaining < len(admins) && remaining == 0
+}
+
+// Restore restores a soft-deleted user. It returns apperrors.ErrUserNotFound if
+// no soft-deleted user with the given ID exists, and apperrors.ErrEmailTaken
+// if the email was registered again since the user was deleted.
+func (r *userRepository) Restore(ctx context.Context, id uint) error {
+	ctx, cancel := withQueryTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	result := r.db.WithContext(ctx).Unscoped().
+		Model(&entity.User{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return r.userError(ctx, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.ErrUserNotFound
+	}
+	return nil
+}
+
+// PurgeByID permanently deletes a user, including soft-deleted users. It
+// returns apperrors.ErrUserNotFound if no user with the given ID exists.
+func (r *userRepository) PurgeByID(ctx context.Context, id uint) error {
+	ctx, cancel := withQueryTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	result := r.db.WithContext(ctx).Unscoped().Delete(&entity.User{}, id)
+	if result.Error != nil {
+		return r.dbError(ctx, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.ErrUserNotFound
+	}
+	return nil
+}
+
+// PurgeDeletedBefore permanently deletes the users soft-deleted before cutoff
+// and returns how many were deleted
+func (r *userRepository) PurgeDeletedBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	ctx, cancel := withQueryTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	result := r.db.WithContext(ctx).Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).
+		Delete(&entity.User{})
+	if result.Error != nil {
+		return 0, r.dbError(ctx, result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// userError translates a GORM error like dbError, except that a unique
+// violation becomes apperrors.ErrEmailTaken, email being the only unique
+// column besides the primary key
+func (r *userRepository) userError(ctx context.Context, err error) error {
+	if isUniqueViolation(err) {
+		return apperrors.WrapError(apperrors.ErrEmailTaken, err)
+	}
+	return r.dbError(ctx, err)
+}
+
+// filterUsers returns a scope applying the user filter
+func filterUsers(filter UserFilter) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if filter.IncludeDeleted {
+			db = db.Unscoped()
+		}
+		if filter.Role != "" {
+			db = db.Where("role = ?", filter.Role)
+		}
+		if filter.Status != "" {
+			db = db.Where("status = ?", filter.Status)
+		}
+		if filter.IsActive != nil {
+			if *filter.IsActive {
+				db = db.Where("status = ?", constants.UserStatusActive)
+			} else {
+				db = db.Where("status <> ?", constants.UserStatusActive)
+			}
+		}
+		if filter.Search != "" {
+			pattern := "%" + escapeLike(strings.ToLower(filter.Search)) + "%"
+			// Explicit ESCAPE since SQLite, unlike Postgres, has no default
+			db = db.Where(`LOWER(name) LIKE ? ESCAPE '\' OR LOWER(email) LIKE ? ESCAPE '\'`, pattern, pattern)
+		}
+		return db
+	}
+}
+
+// rankUserSearch returns a scope ordering search results by relevance, using
+// plain SQL so it works on both Postgres and SQLite
+func rankUserSearch(query string) func(*gorm.DB) *gorm.DB {
+	query = strings.ToLower(query)
+	prefix := escapeLike(query) + "%"
+
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Order(clause.OrderBy{Expression: clause.Expr{
+			SQL: `CASE WHEN LOWER(email) = ? THEN 0 ` +
+				`WHEN LOWER(email) LIKE ? ESCAPE '\' OR LOWER(name) LIKE ? ESCAPE '\' THEN 1 ` +
+				`ELSE 2 END, id`,
+			Vars:               []interface{}{query, prefix, prefix},
+			WithoutParentheses: true,
+		}})
+	}
+}
+
+// likeEscaper escapes LIKE wildcards so user input matches literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// escapeLike escapes a string for use inside a LIKE pattern
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
 }
@@ -0,0 +1,308 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/your-username/go-clean-architecture/config"
+	"github.com/your-username/go-clean-architecture/internal/entity"
+	"github.com/your-username/go-clean-architecture/pkg/apperrors"
+	"github.com/your-username/go-clean-architecture/pkg/database"
+	"github.com/your-username/go-clean-architecture/pkg/database/dbtest"
+	"gorm.io/gorm"
+)
+
+// newTestUserRepository returns a user repository over db whose
+// transactions are not retried
+func newTestUserRepository(db *gorm.DB, queryTimeout time.Duration, hardDelete bool) UserRepository {
+	return NewUserRepository(db, database.NewTransactor(db, config.RetryConfig{}), queryTimeout, hardDelete)
+}
+
+// seedUsers creates active users with the given names, emails derived from them
+func seedUsers(t *testing.T, db *gorm.DB, names ...string) []entity.User {
+	t.Helper()
+	users := make([]entity.User, 0, len(names))
+	for _, name := range names {
+		user := entity.User{Name: name, Email: name + "@example.com", Password: "hash", Role: "user", Status: "active"}
+		if err := db.Create(&user).Error; err != nil {
+			t.Fatalf("failed to seed user %s: %v", name, err)
+		}
+		users = append(users, user)
+	}
+	return users
+}
+
+func TestUserRepositorySearchMatchesWildcardsLiterally(t *testing.T) {
+	db := dbtest.New(t, &entity.User{})
+	repo := newTestUserRepository(db, 0, false)
+	seedUsers(t, db, "100%_sure", "1000 things", "plain")
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{query: "100%", want: []string{"100%_sure"}},
+		{query: "%_", want: []string{"100%_sure"}},
+		{query: "100", want: []string{"100%_sure", "1000 things"}},
+		{query: "PLAIN", want: []string{"plain"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			users, total, err := repo.Search(context.Background(), UserFilter{Search: tt.query}, 1, 10)
+			if err != nil {
+				t.Fatalf("Search() error = %v", err)
+			}
+			if total != int64(len(tt.want)) || len(users) != len(tt.want) {
+				t.Fatalf("Search() returned %d users (total %d), want %v", len(users), total, tt.want)
+			}
+			for i, user := range users {
+				if user.Name != tt.want[i] {
+					t.Errorf("Search()[%d] = %s, want %s", i, user.Name, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+// slowQuery is an SQLite statement that runs until it is interrupted, like a
+// query waiting on a lock
+const slowQuery = "WITH RECURSIVE spin(n) AS (SELECT 1 UNION ALL SELECT n + 1 FROM spin) SELECT max(n) FROM spin"
+
+// stallQueries makes every statement on db run slowQuery first, with the
+// statement's context
+func stallQueries(t *testing.T, db *gorm.DB) {
+	t.Helper()
+	stall := func(tx *gorm.DB) {
+		var n int64
+		if err := tx.Statement.ConnPool.QueryRowContext(tx.Statement.Context, slowQuery).Scan(&n); err != nil {
+			_ = tx.AddError(err)
+		}
+	}
+
+	callbacks := db.Callback()
+	for _, err := range []error{
+		callbacks.Create().Before("gorm:create").Register("test:stall", stall),
+		callbacks.Query().Before("gorm:query").Register("test:stall", stall),
+		callbacks.Update().Before("gorm:update").Register("test:stall", stall),
+		callbacks.Delete().Before("gorm:delete").Register("test:stall", stall),
+	} {
+		if err != nil {
+			t.Fatalf("failed to register stall callback: %v", err)
+		}
+	}
+}
+
+func TestUserRepositoryQueryTimeout(t *testing.T) {
+	tests := []struct {
+		name string
+		call func(ctx context.Context, repo UserRepository) error
+	}{
+		{name: "FindByID", call: func(ctx context.Context, repo UserRepository) error {
+			_, err := repo.FindByID(ctx, 1)
+			return err
+		}},
+		{name: "FindByEmail", call: func(ctx context.Context, repo UserRepository) error {
+			_, err := repo.FindByEmail(ctx, "jane@example.com")
+			return err
+		}},
+		{name: "Create", call: func(ctx context.Context, repo UserRepository) error {
+			return repo.Create(ctx, &entity.User{Name: "John", Email: "john@example.com", Password: "hash", Role: "user", Status: "active"})
+		}},
+		{name: "UpdatePartial", call: func(ctx context.Context, repo UserRepository) error {
+			return repo.UpdatePartial(ctx, 1, map[string]interface{}{"name": "Janet"})
+		}},
+		{name: "Delete", call: func(ctx context.Context, repo UserRepository) error {
+			return repo.Delete(ctx, 1)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := dbtest.New(t, &entity.User{})
+			seedUsers(t, db, "jane")
+			stallQueries(t, db)
+			repo := newTestUserRepository(db, 100*time.Millisecond, false)
+
+			start := time.Now()
+			err := tt.call(context.Background(), repo)
+			if !errors.Is(err, ErrQueryTimeout) || !errors.Is(err, apperrors.ErrQueryTimeout) {
+				t.Fatalf("%s() error = %v, want ErrQueryTimeout", tt.name, err)
+			}
+			if errors.Is(err, apperrors.ErrUserNotFound) {
+				t.Errorf("%s() timeout reported as not found: %v", tt.name, err)
+			}
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Errorf("%s() took %s, want it bounded by the query timeout", tt.name, elapsed)
+			}
+		})
+	}
+}
+
+func TestUserRepositoryQueryContext(t *testing.T) {
+	t.Run("caller deadline", func(t *testing.T) {
+		db := dbtest.New(t, &entity.User{})
+		stallQueries(t, db)
+		// The query timeout is far off, so only the caller's deadline ends the query
+		repo := newTestUserRepository(db, time.Minute, false)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		if _, err := repo.FindByID(ctx, 1); !errors.Is(err, ErrQueryTimeout) {
+			t.Fatalf("FindByID() error = %v, want ErrQueryTimeout", err)
+		}
+	})
+
+	t.Run("caller cancels", func(t *testing.T) {
+		db := dbtest.New(t, &entity.User{})
+		stallQueries(t, db)
+		repo := newTestUserRepository(db, time.Minute, false)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(100*time.Millisecond, cancel)
+		_, err := repo.FindByID(ctx, 1)
+		if !errors.Is(err, ErrQueryCanceled) || errors.Is(err, ErrQueryTimeout) {
+			t.Fatalf("FindByID() error = %v, want ErrQueryCanceled", err)
+		}
+	})
+
+	t.Run("missing record", func(t *testing.T) {
+		db := dbtest.New(t, &entity.User{})
+		repo := newTestUserRepository(db, time.Second, false)
+
+		_, err := repo.FindByID(context.Background(), 1)
+		if !errors.Is(err, apperrors.ErrUserNotFound) || errors.Is(err, ErrQueryTimeout) {
+			t.Fatalf("FindByID() error = %v, want ErrUserNotFound", err)
+		}
+	})
+}
+
+func TestUserRepositoryDeleteMode(t *testing.T) {
+	tests := []struct {
+		name       string
+		hardDelete bool
+	}{
+		{name: "soft", hardDelete: false},
+		{name: "hard", hardDelete: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := dbtest.New(t, &entity.User{})
+			repo := newTestUserRepository(db, time.Second, tt.hardDelete)
+			users := seedUsers(t, db, "jane", "john", "joan")
+			ctx := context.Background()
+
+			if err := repo.Delete(ctx, users[0].ID); err != nil {
+				t.Fatalf("Delete() error = %v", err)
+			}
+			deleted, err := repo.DeleteByIDs(ctx, []uint{users[1].ID, users[0].ID})
+			if err != nil {
+				t.Fatalf("DeleteByIDs() error = %v", err)
+			}
+			// The user deleted before is not deleted again, in either mode
+			if len(deleted) != 1 || deleted[0] != users[1].ID {
+				t.Errorf("DeleteByIDs() = %v, want [%d]", deleted, users[1].ID)
+			}
+
+			for _, user := range users[:2] {
+				if _, err := repo.FindByID(ctx, user.ID); !errors.Is(err, apperrors.ErrUserNotFound) {
+					t.Errorf("FindByID(%s) after deleting error = %v, want ErrUserNotFound", user.Name, err)
+				}
+				_, err := repo.FindByIDWithDeleted(ctx, user.ID)
+				if kept := err == nil; kept != !tt.hardDelete {
+					t.Errorf("%s kept = %v after deleting in %s mode (FindByIDWithDeleted() error = %v)", user.Name, kept, tt.name, err)
+				}
+			}
+			if _, err := repo.FindByID(ctx, users[2].ID); err != nil {
+				t.Errorf("FindByID(%s) error = %v, want the user kept", users[2].Name, err)
+			}
+
+			// Only soft-deleted users can be restored
+			err = repo.Restore(ctx, users[0].ID)
+			if restored := err == nil; restored != !tt.hardDelete {
+				t.Errorf("Restore() in %s mode error = %v", tt.name, err)
+			}
+		})
+	}
+}
+
+func TestUserRepositoryDeleteByIDsKeepsAnActiveAdmin(t *testing.T) {
+	tests := []struct {
+		name string
+		// delete names the users to delete, of admins a and b, inactive
+		// admin c and user jane
+		delete  []string
+		want    error
+		deleted int
+	}{
+		{name: "one of two admins", delete: []string{"a", "jane"}, deleted: 2},
+		{name: "both admins", delete: []string{"a", "b"}, want: apperrors.ErrLastAdmin},
+		{name: "both admins and a user", delete: []string{"jane", "a", "b"}, want: apperrors.ErrLastAdmin},
+		{name: "inactive admin", delete: []string{"c"}, deleted: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := dbtest.New(t, &entity.User{})
+			repo := newTestUserRepository(db, time.Second, false)
+			users := map[string]uint{"jane": seedUsers(t, db, "jane")[0].ID}
+			for name, status := range map[string]string{"a": "active", "b": "active", "c": "inactive"} {
+				admin := entity.User{Name: name, Email: name + "@example.com", Password: "hash", Role: "admin", Status: status}
+				if err := db.Create(&admin).Error; err != nil {
+					t.Fatalf("failed to seed admin %s: %v", name, err)
+				}
+				users[name] = admin.ID
+			}
+
+			ids := make([]uint, 0, len(tt.delete))
+			for _, name := range tt.delete {
+				ids = append(ids, users[name])
+			}
+			deleted, err := repo.DeleteByIDs(context.Background(), ids)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("DeleteByIDs() error = %v, want %v", err, tt.want)
+			}
+			if len(deleted) != tt.deleted {
+				t.Errorf("DeleteByIDs() deleted %v, want %d users", deleted, tt.deleted)
+			}
+
+			// A rejected batch deletes nothing
+			var remaining int64
+			db.Model(&entity.User{}).Count(&remaining)
+			if want := int64(4 - tt.deleted); remaining != want {
+				t.Errorf("%d users remain, want %d", remaining, want)
+			}
+		})
+	}
+}
+
+func TestUserRepositoryExistsByEmail(t *testing.T) {
+	db := dbtest.New(t, &entity.User{})
+	repo := newTestUserRepository(db, time.Second, false)
+	users := seedUsers(t, db, "jane", "john")
+	if err := repo.Delete(context.Background(), users[1].ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	tests := []struct {
+		email string
+		want  bool
+	}{
+		{email: "jane@example.com", want: true},
+		// A deleted user's email can be registered again
+		{email: "john@example.com", want: false},
+		{email: "nobody@example.com", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.email, func(t *testing.T) {
+			exists, err := repo.ExistsByEmail(context.Background(), tt.email)
+			if err != nil || exists != tt.want {
+				t.Errorf("ExistsByEmail(%s) = %v, %v; want %v", tt.email, exists, err, tt.want)
+			}
+		})
+	}
+}
@@ -1,92 +1,177 @@
 package router
 
 import (
+	"strings"
+
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"github.com/your-username/go-clean-architecture/config"
+	"github.com/your-username/go-clean-architecture/docs"
 	"github.com/your-username/go-clean-architecture/internal/handler"
 	"github.com/your-username/go-clean-architecture/internal/middleware"
+	"github.com/your-username/go-clean-architecture/pkg/database"
+	"github.com/your-username/go-clean-architecture/pkg/logger"
+	"github.com/your-username/go-clean-architecture/pkg/metrics"
+	"github.com/your-username/go-clean-architecture/pkg/storage"
 	"github.com/your-username/go-clean-architecture/pkg/utils"
 )
 
+// multipartOverhead is added to upload limits to leave room for multipart
+// boundaries and headers
+const multipartOverhead = 64 << 10
+
+// RouteRegistrar registers a handler's routes on an API version group
+type RouteRegistrar interface {
+	RegisterRoutes(rg *gin.RouterGroup, mw handler.RouteMiddleware)
+}
+
+// apiVersion is an API version prefix and the handlers serving it
+type apiVersion struct {
+	prefix string
+	routes []RouteRegistrar
+}
+
 // Router holds all route configurations
 type Router struct {
-	engine        *gin.Engine
-	userHandler   *handler.UserHandler
-	healthHandler *handler.HealthHandler
-	jwtManager    *utils.JWTManager
+	engine          *gin.Engine
+	userHandler     *handler.UserHandler
+	auditLogHandler *handler.AuditLogHandler
+	healthHandler   *handler.HealthHandler
+	eventHandler    *handler.EventHandler
+	jwtManager      *utils.JWTManager
+	tokenVersions   middleware.TokenVersionSource
+	shutdownSignal  middleware.ShutdownSignal
+	redis           *database.RedisClient
+	cfg             *config.Config
 }
 
 // NewRouter creates a new router instance
 func NewRouter(
 	userHandler *handler.UserHandler,
+	auditLogHandler *handler.AuditLogHandler,
 	healthHandler *handler.HealthHandler,
+	eventHandler *handler.EventHandler,
 	jwtManager *utils.JWTManager,
-	debug bool,
+	tokenVersions middleware.TokenVersionSource,
+	shutdownSignal middleware.ShutdownSignal,
+	redis *database.RedisClient,
+	cfg *config.Config,
 ) *Router {
-	if debug {
+	if cfg.App.Debug {
 		gin.SetMode(gin.DebugMode)
 	} else {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
 	engine := gin.New()
+	// Only these proxies may set the client IP through forwarding headers,
+	// otherwise any client could spoof it in logs and audit entries
+	if err := engine.SetTrustedProxies(cfg.App.TrustedProxies); err != nil {
+		logger.Fatalf("Invalid TRUSTED_PROXIES: %v", err)
+	}
 
 	return &Router{
-		engine:        engine,
-		userHandler:   userHandler,
-		healthHandler: healthHandler,
-		jwtManager:    jwtManager,
+		engine:          engine,
+		userHandler:     userHandler,
+		auditLogHandler: auditLogHandler,
+		healthHandler:   healthHandler,
+		eventHandler:    eventHandler,
+		jwtManager:      jwtManager,
+		tokenVersions:   tokenVersions,
+		shutdownSignal:  shutdownSignal,
+		redis:           redis,
+		cfg:             cfg,
 	}
 }
 
 // SetupRoutes sets up all routes
 func (r *Router) SetupRoutes() *gin.Engine {
 	// Global middleware
-	r.engine.Use(middleware.RecoveryMiddleware())
-	r.engine.Use(middleware.LoggerMiddleware())
-	r.engine.Use(middleware.CORSMiddleware())
+	if r.cfg.Tracing.Enabled() {
+		r.engine.Use(middleware.TracingMiddleware())
+	}
+	r.engine.Use(middleware.MetricsMiddleware())
+	r.engine.Use(middleware.RecoveryMiddleware(r.cfg.App.Debug))
+	r.engine.Use(middleware.LoggerMiddleware(r.cfg.Log.AccessFields))
+	// Only the liveness probe keeps answering while shutting down, a failing
+	// one would get the instance killed before it drains
+	r.engine.Use(middleware.DrainMiddleware(r.shutdownSignal, r.cfg.App.BasePath+"/health"))
+	r.engine.Use(middleware.CORSMiddleware(r.cfg.CORS))
+	r.engine.Use(middleware.CompressionMiddleware(r.cfg.Compression))
+	r.engine.Use(middleware.ErrorMiddleware(r.cfg.App.Debug))
+	if r.cfg.App.Debug && r.cfg.Log.Bodies {
+		r.engine.Use(middleware.BodyLogMiddleware(r.cfg.Log))
+	}
+	r.engine.Use(middleware.BodyLimitMiddleware(r.cfg.App.MaxBodyBytes))
+	r.engine.Use(middleware.AuditContextMiddleware())
+	if r.cfg.App.Debug && r.cfg.App.OpenAPIValidation != config.OpenAPIValidationOff {
+		openAPI, err := middleware.OpenAPIMiddleware(docs.SwaggerInfo.ReadDoc(), r.cfg.App.BasePath, r.cfg.App.OpenAPIValidation, "/swagger", "/health", "/ready", "/metrics")
+		if err != nil {
+			logger.Warnf("OpenAPI validation disabled: %v", err)
+		} else {
+			r.engine.Use(openAPI)
+		}
+	}
+
+	// Probes and scrapes are frequent, so their access logs are reduced
+	quietLog := middleware.AccessLogFields(middleware.AccessFieldStatusCode, middleware.AccessFieldLatency, middleware.AccessFieldMethod, middleware.AccessFieldPath)
+
+	// Every route lives under the configured base path
+	root := r.engine.Group(r.cfg.App.BasePath)
 
 	// Health check routes (no auth required)
-	r.engine.GET("/health", r.healthHandler.Health)
-	r.engine.GET("/ready", r.healthHandler.Ready)
-
-	// Swagger documentation
-	r.engine.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
-
-	// API v1 routes
-	v1 := r.engine.Group("/api/v1")
-	{
-		// Auth routes (public)
-		auth := v1.Group("/auth")
-		{
-			auth.POST("/register", r.userHandler.Register)
-			auth.POST("/login", r.userHandler.Login)
-		}
+	root.GET("/health", quietLog, r.healthHandler.Health)
+	root.GET("/ready", quietLog, r.healthHandler.Ready)
 
-		// User routes (protected)
-		users := v1.Group("/users")
-		users.Use(middleware.AuthMiddleware(r.jwtManager))
-		{
-			users.GET("/me", r.userHandler.GetCurrentUser)
-			users.GET("", r.userHandler.GetUsers)
-			users.GET("/:id", r.userHandler.GetUser)
-			users.PUT("/:id", r.userHandler.UpdateUser)
-			users.DELETE("/:id", r.userHandler.DeleteUser)
-		}
+	// Prometheus metrics
+	root.GET("/metrics", quietLog, gin.WrapH(metrics.Handler()))
+
+	// Locally stored uploads. STORAGE_BASE_URL is used as is, since it is
+	// also the public URL of stored files.
+	if r.cfg.Storage.Driver == storage.DriverLocal && strings.HasPrefix(r.cfg.Storage.BaseURL, "/") {
+		r.engine.Static(r.cfg.Storage.BaseURL, r.cfg.Storage.LocalDir)
+	}
+
+	// Swagger documentation. Documented paths are relative to the base path.
+	docs.SwaggerInfo.BasePath = r.cfg.App.BasePath
+	if docs.SwaggerInfo.BasePath == "" {
+		docs.SwaggerInfo.BasePath = "/"
+	}
+	root.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+
+	// Shared by every API version, see handler.RouteMiddleware
+	mw := handler.RouteMiddleware{
+		Timeout:         middleware.TimeoutMiddleware(r.cfg.App.RequestTimeout),
+		Authenticated:   middleware.AuthMiddleware(r.jwtManager, r.tokenVersions),
+		AdminOnly:       middleware.RoleMiddleware("admin"),
+		FreshAuth:       middleware.RequireFreshAuth(r.cfg.JWT.FreshAuthMaxAge),
+		Idempotent:      middleware.IdempotencyMiddleware(r.redis, r.cfg.Idempotency),
+		AvatarBodyLimit: middleware.BodyLimitMiddleware(r.cfg.Avatar.MaxBytes + multipartOverhead),
+	}
 
-		// Admin routes (protected with role check)
-		admin := v1.Group("/admin")
-		admin.Use(middleware.AuthMiddleware(r.jwtManager))
-		admin.Use(middleware.RoleMiddleware("admin"))
-		{
-			// Add admin-only routes here
+	for _, version := range r.apiVersions() {
+		group := root.Group(version.prefix)
+		for _, routes := range version.routes {
+			routes.RegisterRoutes(group, mw)
 		}
 	}
 
 	return r.engine
 }
 
+// apiVersions lists the API versions served side by side. A version reuses a
+// handler of an earlier one by listing it again, and overrides its routes by
+// listing a handler of its own instead, e.g. a v2 user handler with a new
+// response envelope in place of r.userHandler.
+func (r *Router) apiVersions() []apiVersion {
+	return []apiVersion{
+		{prefix: "/api/v1", routes: []RouteRegistrar{r.userHandler, r.auditLogHandler, r.eventHandler}},
+		// No breaking changes yet, so v2 serves nothing
+		{prefix: "/api/v2"},
+	}
+}
+
 // GetEngine returns the gin engine
 func (r *Router) GetEngine() *gin.Engine {
 	return r.engine
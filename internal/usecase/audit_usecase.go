@@ -0,0 +1,199 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/your-username/go-clean-architecture/internal/dto"
+	"github.com/your-username/go-clean-architecture/internal/entity"
+	"github.com/your-username/go-clean-architecture/internal/repository"
+	"github.com/your-username/go-clean-architecture/pkg/audit"
+	"github.com/your-username/go-clean-architecture/pkg/logger"
+	"github.com/your-username/go-clean-architecture/pkg/pagination"
+)
+
+// Audit actions
+const (
+	AuditActionRegister     = "user.register"
+	AuditActionLogin        = "user.login"
+	AuditActionRoleChange   = "user.role_change"
+	AuditActionDelete       = "user.delete"
+	AuditActionRestore      = "user.restore"
+	AuditActionPurge        = "user.purge"
+	AuditActionLogoutAll    = "user.logout_all"
+	AuditActionStatusChange = "user.status_change"
+)
+
+// Audit target types
+const (
+	AuditTargetUser = "user"
+)
+
+const (
+	// auditBufferSize is the number of entries that can wait to be written
+	auditBufferSize = 256
+	// auditWriteTimeout bounds each audit log insert
+	auditWriteTimeout = 5 * time.Second
+)
+
+// AuditEntry describes an operation to record in the audit log
+type AuditEntry struct {
+	// ActorID defaults to the authenticated user carried by the context
+	ActorID    uint
+	Action     string
+	TargetType string
+	TargetID   uint
+	Metadata   map[string]interface{}
+}
+
+// AuditUseCase defines the audit log use case interface
+type AuditUseCase interface {
+	Record(ctx context.Context, entry AuditEntry)
+	GetAll(ctx context.Context, filter *dto.AuditLogFilterRequest, page, limit int, mode pagination.TotalMode) ([]dto.AuditLogResponse, int64, error)
+	Close(ctx context.Context) error
+}
+
+type auditUseCase struct {
+	auditRepo repository.AuditLogRepository
+	entries   chan *entity.AuditLog
+	done      chan struct{}
+	mu        sync.RWMutex
+	closed    bool
+}
+
+// NewAuditUseCase creates a new audit use case. Entries are written by a
+// background worker so recording never adds latency to the request.
+func NewAuditUseCase(auditRepo repository.AuditLogRepository) AuditUseCase {
+	u := &auditUseCase{
+		auditRepo: auditRepo,
+		entries:   make(chan *entity.AuditLog, auditBufferSize),
+		done:      make(chan struct{}),
+	}
+	go u.run()
+	return u
+}
+
+// Record queues an audit entry without blocking. Entries are dropped with a
+// warning if the buffer is full.
+func (u *auditUseCase) Record(ctx context.Context, entry AuditEntry) {
+	log := &entity.AuditLog{
+		Action:     entry.Action,
+		TargetType: entry.TargetType,
+		IP:         audit.ClientIPFromContext(ctx),
+		Metadata:   "{}",
+		CreatedAt:  time.Now(),
+	}
+
+	actorID := entry.ActorID
+	if actorID == 0 {
+		actorID, _ = audit.ActorFromContext(ctx)
+	}
+	if actorID != 0 {
+		log.ActorID = &actorID
+	}
+	if entry.TargetID != 0 {
+		targetID := entry.TargetID
+		log.TargetID = &targetID
+	}
+	if len(entry.Metadata) > 0 {
+		if metadata, err := json.Marshal(entry.Metadata); err == nil {
+			log.Metadata = string(metadata)
+		}
+	}
+
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	if u.closed {
+		logAuditDrop(log, "audit log closed")
+		return
+	}
+
+	select {
+	case u.entries <- log:
+	default:
+		logAuditDrop(log, "audit log buffer full")
+	}
+}
+
+// GetAll gets audit log entries matching the filter with pagination,
+// counting them as mode asks
+func (u *auditUseCase) GetAll(ctx context.Context, filter *dto.AuditLogFilterRequest, page, limit int, mode pagination.TotalMode) ([]dto.AuditLogResponse, int64, error) {
+	var repoFilter repository.AuditLogFilter
+	if filter != nil {
+		repoFilter.ActorID = filter.ActorID
+		repoFilter.Action = filter.Action
+	}
+
+	logs, total, err := u.auditRepo.FindAll(ctx, repoFilter, page, limit, mode)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	response := make([]dto.AuditLogResponse, 0, len(logs))
+	for _, log := range logs {
+		response = append(response, dto.AuditLogResponse{
+			ID:         log.ID,
+			ActorID:    log.ActorID,
+			Action:     log.Action,
+			TargetType: log.TargetType,
+			TargetID:   log.TargetID,
+			Metadata:   json.RawMessage(log.Metadata),
+			IP:         log.IP,
+			CreatedAt:  dto.NewTimestamp(log.CreatedAt),
+		})
+	}
+
+	return response, total, nil
+}
+
+// Close stops accepting entries and waits for queued entries to be written
+func (u *auditUseCase) Close(ctx context.Context) error {
+	u.mu.Lock()
+	if !u.closed {
+		u.closed = true
+		close(u.entries)
+	}
+	u.mu.Unlock()
+
+	select {
+	case <-u.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run writes queued entries until the queue is closed
+func (u *auditUseCase) run() {
+	defer close(u.done)
+
+	for log := range u.entries {
+		ctx, cancel := context.WithTimeout(context.Background(), auditWriteTimeout)
+		if err := u.auditRepo.Create(ctx, log); err != nil {
+			logger.WithFields(logrus.Fields{
+				"action": log.Action,
+				"error":  err,
+			}).Error("Failed to write audit log")
+		}
+		cancel()
+	}
+}
+
+// logAuditDrop logs an entry that could not be queued
+func logAuditDrop(log *entity.AuditLog, reason string) {
+	fields := logrus.Fields{
+		"action":      log.Action,
+		"target_type": log.TargetType,
+	}
+	if log.ActorID != nil {
+		fields["actor_id"] = *log.ActorID
+	}
+	if log.TargetID != nil {
+		fields["target_id"] = *log.TargetID
+	}
+	logger.WithFields(fields).Warnf("Dropped audit log entry: %s", reason)
+}
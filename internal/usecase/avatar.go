@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"  // register GIF decoder
+	_ "image/jpeg" // register JPEG decoder
+	_ "image/png"  // register PNG decoder
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/your-username/go-clean-architecture/internal/dto"
+	"github.com/your-username/go-clean-architecture/pkg/apperrors"
+	"github.com/your-username/go-clean-architecture/pkg/database"
+	"github.com/your-username/go-clean-architecture/pkg/logger"
+)
+
+// avatarExtensions maps accepted image content types to file extensions
+var avatarExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+}
+
+// UploadAvatar validates an uploaded image and stores it as the user's avatar.
+// The content type is sniffed from the file itself rather than trusted from
+// the client, and the image header is decoded to check its dimensions.
+func (u *userUseCase) UploadAvatar(ctx context.Context, id uint, file io.ReadSeeker, size int64) (*dto.UserResponse, error) {
+	if u.avatarCfg.MaxBytes > 0 && size > u.avatarCfg.MaxBytes {
+		return nil, apperrors.NewAppError(apperrors.ErrPayloadTooLarge.Code, apperrors.SlugPayloadTooLarge,
+			fmt.Sprintf("Avatar must be at most %d bytes", u.avatarCfg.MaxBytes), nil)
+	}
+
+	user, err := u.userRepo.FindByID(database.WithPrimary(ctx), id)
+	if err != nil {
+		return nil, err
+	}
+
+	sniff := make([]byte, 512)
+	n, err := io.ReadFull(file, sniff)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, apperrors.WrapError(apperrors.ErrInvalidImage, err)
+	}
+	contentType := http.DetectContentType(sniff[:n])
+	ext, ok := avatarExtensions[contentType]
+	if !ok {
+		return nil, apperrors.ErrInvalidImage
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, apperrors.WrapError(apperrors.ErrInternalServer, err)
+	}
+	imgCfg, _, err := image.DecodeConfig(file)
+	if err != nil {
+		return nil, apperrors.WrapError(apperrors.ErrInvalidImage, err)
+	}
+	if maxDim := u.avatarCfg.MaxDimension; maxDim > 0 && (imgCfg.Width > maxDim || imgCfg.Height > maxDim) {
+		return nil, apperrors.NewAppError(apperrors.ErrInvalidImage.Code, apperrors.SlugInvalidImage,
+			fmt.Sprintf("Avatar must be at most %dx%d pixels", maxDim, maxDim), nil)
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, apperrors.WrapError(apperrors.ErrInternalServer, err)
+	}
+	key := avatarKey(id, ext)
+	if err := u.storage.Put(ctx, key, file, contentType); err != nil {
+		return nil, apperrors.WrapError(apperrors.ErrInternalServer, err)
+	}
+
+	// Remove an earlier avatar stored under a different extension
+	for _, otherExt := range avatarExtensions {
+		if otherExt == ext {
+			continue
+		}
+		if err := u.storage.Delete(ctx, avatarKey(id, otherExt)); err != nil {
+			logger.Warnf("Failed to delete old avatar for user %d: %v", id, err)
+		}
+	}
+
+	// The version query busts caches since the key is reused
+	user.AvatarURL = fmt.Sprintf("%s?v=%d", u.storage.URL(key), time.Now().Unix())
+	if err := u.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	u.invalidateUser(ctx, id)
+
+	resp := toUserResponse(user)
+	u.publishUserUpdated(ctx, &resp)
+	return &resp, nil
+}
+
+// avatarKey returns the storage key of a user's avatar
+func avatarKey(id uint, ext string) string {
+	return fmt.Sprintf("avatars/%d%s", id, ext)
+}
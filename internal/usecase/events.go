@@ -0,0 +1,40 @@
+package usecase
+
+import "github.com/your-username/go-clean-architecture/internal/dto"
+
+// Event types published by the use cases. Side effects that are not part of
+// the change itself, such as audit entries and notifications, subscribe to
+// them in RegisterSubscribers. Cache invalidation stays in the use cases
+// since it must be done before the change is reported, or a read right after
+// it could see the old user.
+const (
+	EventUserRegistered = "user.registered"
+	EventUserUpdated    = "user.updated"
+	EventUserDeleted    = "user.deleted"
+)
+
+// UserRegistered is published when a user registers
+type UserRegistered struct {
+	User dto.UserResponse
+}
+
+// EventType implements events.Event
+func (UserRegistered) EventType() string { return EventUserRegistered }
+
+// UserUpdated is published when a user's profile changes, including avatar
+// changes and restores
+type UserUpdated struct {
+	User dto.UserResponse
+}
+
+// EventType implements events.Event
+func (UserUpdated) EventType() string { return EventUserUpdated }
+
+// UserDeleted is published when a user is deleted, softly or not as
+// DELETE_MODE says
+type UserDeleted struct {
+	UserID uint
+}
+
+// EventType implements events.Event
+func (UserDeleted) EventType() string { return EventUserDeleted }
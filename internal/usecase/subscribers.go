@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/your-username/go-clean-architecture/pkg/events"
+	"github.com/your-username/go-clean-architecture/pkg/mail"
+	"github.com/your-username/go-clean-architecture/pkg/realtime"
+)
+
+// RegisterSubscribers subscribes the side effects of the use case events:
+// audit entries for registrations and deletions, and user.updated
+// notifications on the user's open event streams
+func RegisterSubscribers(bus *events.Bus, auditUseCase AuditUseCase, streams realtime.Publisher) {
+	bus.Subscribe(EventUserRegistered, func(ctx context.Context, event events.Event) error {
+		user := event.(UserRegistered).User
+		auditUseCase.Record(ctx, AuditEntry{
+			ActorID:    user.ID,
+			Action:     AuditActionRegister,
+			TargetType: AuditTargetUser,
+			TargetID:   user.ID,
+		})
+		return nil
+	})
+
+	bus.Subscribe(EventUserDeleted, func(ctx context.Context, event events.Event) error {
+		auditUseCase.Record(ctx, AuditEntry{
+			Action:     AuditActionDelete,
+			TargetType: AuditTargetUser,
+			TargetID:   event.(UserDeleted).UserID,
+		})
+		return nil
+	})
+
+	bus.Subscribe(EventUserUpdated, func(ctx context.Context, event events.Event) error {
+		user := event.(UserUpdated).User
+		return streams.Publish(ctx, user.ID, realtime.Event{Type: realtime.EventUserUpdated, Data: user})
+	})
+}
+
+// TemplateSender sends an email rendered from a named template
+type TemplateSender interface {
+	SendTemplate(ctx context.Context, to, subject, templateName string, data interface{}) error
+}
+
+// SubscribeWelcomeEmail sends registered users the welcome email. Sending is
+// fire-and-forget: a failure is only logged and never affects registration.
+func SubscribeWelcomeEmail(bus *events.Bus, mailer TemplateSender, appName string) {
+	bus.Subscribe(EventUserRegistered, func(ctx context.Context, event events.Event) error {
+		user := event.(UserRegistered).User
+		subject := fmt.Sprintf("Welcome to %s", appName)
+		data := struct{ Name, Email string }{Name: user.Name, Email: user.Email}
+		if err := mailer.SendTemplate(ctx, user.Email, subject, mail.TemplateWelcome, data); err != nil {
+			return fmt.Errorf("failed to send welcome email to user %d: %w", user.ID, err)
+		}
+		return nil
+	})
+}
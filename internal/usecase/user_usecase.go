@@ -3,46 +3,104 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
 
+	"github.com/your-username/go-clean-architecture/config"
 	"github.com/your-username/go-clean-architecture/internal/dto"
 	"github.com/your-username/go-clean-architecture/internal/entity"
 	"github.com/your-username/go-clean-architecture/internal/repository"
+	"github.com/your-username/go-clean-architecture/pkg/apperrors"
+	"github.com/your-username/go-clean-architecture/pkg/auth"
+	"github.com/your-username/go-clean-architecture/pkg/cache"
+	"github.com/your-username/go-clean-architecture/pkg/constants"
+	"github.com/your-username/go-clean-architecture/pkg/database"
+	"github.com/your-username/go-clean-architecture/pkg/events"
+	"github.com/your-username/go-clean-architecture/pkg/logger"
+	"github.com/your-username/go-clean-architecture/pkg/pagination"
+	"github.com/your-username/go-clean-architecture/pkg/permission"
+	"github.com/your-username/go-clean-architecture/pkg/storage"
 	"github.com/your-username/go-clean-architecture/pkg/utils"
-	"gorm.io/gorm"
 )
 
 // UserUseCase defines the user use case interface
 type UserUseCase interface {
 	Register(ctx context.Context, req *dto.RegisterRequest) (*dto.UserResponse, error)
 	Login(ctx context.Context, req *dto.LoginRequest) (*dto.LoginResponse, error)
+	Introspect(ctx context.Context, req *dto.IntrospectRequest) *dto.IntrospectResponse
+	LogoutAll(ctx context.Context, userID uint) error
+	TokenVersion(ctx context.Context, userID uint) (uint, error)
+	Permissions(ctx context.Context, userID uint, role string) *dto.PermissionsResponse
 	GetByID(ctx context.Context, id uint) (*dto.UserResponse, error)
-	GetAll(ctx context.Context, page, limit int) ([]dto.UserResponse, int64, error)
+	GetByIDs(ctx context.Context, ids []uint) (*dto.BatchGetUsersResponse, error)
+	GetAll(ctx context.Context, filter *dto.UserFilterRequest, page, limit int, mode pagination.TotalMode) ([]dto.UserResponse, int64, error)
+	Search(ctx context.Context, req *dto.UserSearchRequest, page, limit int) ([]dto.UserResponse, int64, error)
+	Export(ctx context.Context, req *dto.UserExportRequest, fn func([]dto.UserResponse) error) error
 	Update(ctx context.Context, id uint, req *dto.UpdateUserRequest) (*dto.UserResponse, error)
+	Patch(ctx context.Context, id uint, req *dto.PatchUserRequest) (*dto.UserResponse, error)
 	Delete(ctx context.Context, id uint) error
+	DeleteByIDs(ctx context.Context, ids []uint) (*dto.BatchDeleteUsersResponse, error)
+	GetByIDWithDeleted(ctx context.Context, id uint) (*dto.UserResponse, error)
+	Restore(ctx context.Context, id uint) (*dto.UserResponse, error)
+	ChangeRole(ctx context.Context, id uint, req *dto.ChangeRoleRequest) (*dto.UserResponse, error)
+	ChangeStatus(ctx context.Context, id uint, req *dto.ChangeStatusRequest) (*dto.UserResponse, error)
+	Activate(ctx context.Context, id uint) (*dto.UserResponse, error)
+	Deactivate(ctx context.Context, id uint, req *dto.DeactivateUserRequest) (*dto.UserResponse, error)
+	Purge(ctx context.Context, id, actorID uint, actorRole string, req *dto.PurgeUserRequest) error
+	PurgeDeleted(ctx context.Context, olderThan time.Duration) error
+	UploadAvatar(ctx context.Context, id uint, file io.ReadSeeker, size int64) (*dto.UserResponse, error)
 }
 
 type userUseCase struct {
-	userRepo   repository.UserRepository
-	jwtManager *utils.JWTManager
+	userRepo     repository.UserRepository
+	jwtManager   *utils.JWTManager
+	auditUseCase AuditUseCase
+	storage      storage.Storage
+	avatarCfg    config.AvatarConfig
+	usersCfg     config.UsersConfig
+	cache        cache.Cache
+	userCacheTTL time.Duration
+	events       events.Publisher
 }
 
 // NewUserUseCase creates a new user use case
-func NewUserUseCase(userRepo repository.UserRepository, jwtManager *utils.JWTManager) UserUseCase {
+func NewUserUseCase(
+	userRepo repository.UserRepository,
+	jwtManager *utils.JWTManager,
+	auditUseCase AuditUseCase,
+	fileStorage storage.Storage,
+	avatarCfg config.AvatarConfig,
+	usersCfg config.UsersConfig,
+	userCache cache.Cache,
+	userCacheTTL time.Duration,
+	bus events.Publisher,
+) UserUseCase {
 	return &userUseCase{
-		userRepo:   userRepo,
-		jwtManager: jwtManager,
+		userRepo:     userRepo,
+		jwtManager:   jwtManager,
+		auditUseCase: auditUseCase,
+		storage:      fileStorage,
+		avatarCfg:    avatarCfg,
+		usersCfg:     usersCfg,
+		cache:        userCache,
+		userCacheTTL: userCacheTTL,
+		events:       bus,
 	}
 }
 
 // Register registers a new user
 func (u *userUseCase) Register(ctx context.Context, req *dto.RegisterRequest) (*dto.UserResponse, error) {
-	// Check if email already exists
-	existingUser, err := u.userRepo.FindByEmail(ctx, req.Email)
-	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+	// Check if email already exists, on the primary so a lagging replica
+	// cannot miss a user registered moments ago
+	existingUser, err := u.userRepo.FindByEmail(database.WithPrimary(ctx), req.Email)
+	if err != nil && !errors.Is(err, apperrors.ErrUserNotFound) {
 		return nil, err
 	}
 	if existingUser != nil {
-		return nil, errors.New("email already registered")
+		return nil, apperrors.ErrEmailTaken
 	}
 
 	// Hash password
@@ -57,22 +115,16 @@ func (u *userUseCase) Register(ctx context.Context, req *dto.RegisterRequest) (*
 		Email:    req.Email,
 		Password: hashedPassword,
 		Role:     "user",
-		IsActive: true,
+		Status:   constants.UserStatusActive,
 	}
 
 	if err := u.userRepo.Create(ctx, user); err != nil {
 		return nil, err
 	}
 
-	return &dto.UserResponse{
-		ID:        user.ID,
-		Name:      user.Name,
-		Email:     user.Email,
-		Role:      user.Role,
-		IsActive:  user.IsActive,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
-	}, nil
+	resp := toUserResponse(user)
+	u.events.Publish(ctx, UserRegistered{User: resp})
+	return &resp, nil
 }
 
 // Login logs in a user
@@ -80,143 +132,735 @@ func (u *userUseCase) Login(ctx context.Context, req *dto.LoginRequest) (*dto.Lo
 	// Find user by email
 	user, err := u.userRepo.FindByEmail(ctx, req.Email)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("invalid email or password")
+		if errors.Is(err, apperrors.ErrUserNotFound) {
+			return nil, apperrors.ErrInvalidCredential
 		}
 		return nil, err
 	}
 
 	// Check password
 	if !utils.CheckPassword(req.Password, user.Password) {
-		return nil, errors.New("invalid email or password")
+		return nil, apperrors.ErrInvalidCredential
 	}
 
-	// Check if user is active
-	if !user.IsActive {
-		return nil, errors.New("account is not active")
+	// Only active users may log in, the others learn why
+	if err := statusError(user.Status); err != nil {
+		return nil, err
+	}
+
+	// Upgrade hashes made with an older, lower cost while we have the password
+	if utils.NeedsRehash(user.Password) {
+		u.rehashPassword(ctx, user, req.Password)
 	}
 
 	// Generate JWT token
-	token, err := u.jwtManager.GenerateToken(user.ID, user.Email, user.Role)
+	token, expiresAt, err := u.jwtManager.GenerateTokenWithExpiry(user.ID, user.Email, user.Role, user.TokenVersion)
 	if err != nil {
 		return nil, err
 	}
 
+	u.auditUseCase.Record(ctx, AuditEntry{
+		ActorID:    user.ID,
+		Action:     AuditActionLogin,
+		TargetType: AuditTargetUser,
+		TargetID:   user.ID,
+	})
+
 	return &dto.LoginResponse{
-		Token: token,
-		User: dto.UserResponse{
-			ID:        user.ID,
-			Name:      user.Name,
-			Email:     user.Email,
-			Role:      user.Role,
-			IsActive:  user.IsActive,
-			CreatedAt: user.CreatedAt,
-			UpdatedAt: user.UpdatedAt,
-		},
+		Token:     token,
+		ExpiresAt: dto.NewTimestamp(expiresAt),
+		ExpiresIn: int(time.Until(expiresAt).Round(time.Second).Seconds()),
+		User:      toUserResponse(user),
 	}, nil
 }
 
-// GetByID gets a user by ID
+// Introspect reports whether a token is active and, if so, its claims.
+// Tokens revoked by LogoutAll are inactive. The reason a token is inactive is
+// deliberately not reported.
+func (u *userUseCase) Introspect(ctx context.Context, req *dto.IntrospectRequest) *dto.IntrospectResponse {
+	claims, err := u.jwtManager.ValidateToken(req.Token)
+	if err != nil {
+		return &dto.IntrospectResponse{Active: false}
+	}
+	if version, err := u.TokenVersion(ctx, claims.UserID); err != nil || version != claims.TokenVersion {
+		return &dto.IntrospectResponse{Active: false}
+	}
+
+	resp := &dto.IntrospectResponse{
+		Active: true,
+		UserID: claims.UserID,
+		Email:  claims.Email,
+		Role:   claims.Role,
+	}
+	if claims.ExpiresAt != nil {
+		resp.ExpiresAt = dto.NewTimestampPtr(claims.ExpiresAt.Time)
+	}
+	if claims.IssuedAt != nil {
+		resp.IssuedAt = dto.NewTimestampPtr(claims.IssuedAt.Time)
+	}
+	return resp
+}
+
+// LogoutAll revokes every token issued to the user so far
+func (u *userUseCase) LogoutAll(ctx context.Context, userID uint) error {
+	if err := u.userRepo.IncrementTokenVersion(ctx, userID); err != nil {
+		return err
+	}
+	u.invalidateUser(ctx, userID)
+
+	u.auditUseCase.Record(ctx, AuditEntry{
+		Action:     AuditActionLogoutAll,
+		TargetType: AuditTargetUser,
+		TargetID:   userID,
+	})
+
+	return nil
+}
+
+// TokenVersion returns the user's current token version. It is read from
+// the primary and never cached, so a token revoked by LogoutAll, ChangeRole or
+// Deactivate is rejected on the very next request, whatever the replica lag.
+func (u *userUseCase) TokenVersion(ctx context.Context, userID uint) (uint, error) {
+	user, err := u.userRepo.FindByID(database.WithPrimary(ctx), userID)
+	if err != nil {
+		return 0, err
+	}
+	return user.TokenVersion, nil
+}
+
+// Permissions resolves the permissions and feature flags of the user's role.
+// It uses the role from the token, so it needs no database access.
+func (u *userUseCase) Permissions(ctx context.Context, userID uint, role string) *dto.PermissionsResponse {
+	return &dto.PermissionsResponse{
+		UserID:      userID,
+		Roles:       []string{role},
+		Permissions: permission.ForRole(role),
+		Features:    permission.FeaturesForRole(role),
+	}
+}
+
+// GetByID gets a user by ID, served from the cache when possible
 func (u *userUseCase) GetByID(ctx context.Context, id uint) (*dto.UserResponse, error) {
+	if err := u.authorizeRead(ctx, id); err != nil {
+		return nil, err
+	}
+
+	if u.userCacheTTL <= 0 {
+		return u.loadUser(ctx, id)
+	}
+
+	var resp dto.UserResponse
+	err := u.cache.Remember(ctx, userCacheKey(id), u.userCacheTTL, &resp, func() (interface{}, error) {
+		return u.loadUser(ctx, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// loadUser reads a user from the repository
+func (u *userUseCase) loadUser(ctx context.Context, id uint) (*dto.UserResponse, error) {
 	user, err := u.userRepo.FindByID(ctx, id)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("user not found")
+		return nil, err
+	}
+
+	resp := toUserResponse(user)
+	return &resp, nil
+}
+
+// GetByIDs gets the users with the given IDs in one query. Duplicate IDs are
+// collapsed and users are returned in the order first requested.
+func (u *userUseCase) GetByIDs(ctx context.Context, ids []uint) (*dto.BatchGetUsersResponse, error) {
+	if err := u.authorizeRead(ctx, ids...); err != nil {
+		return nil, err
+	}
+
+	unique := make([]uint, 0, len(ids))
+	seen := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
 		}
+	}
+	if len(unique) > constants.MaxBatchIDs {
+		return nil, apperrors.NewAppError(http.StatusBadRequest, apperrors.SlugBadRequest,
+			fmt.Sprintf("At most %d IDs can be requested at once", constants.MaxBatchIDs), nil)
+	}
+
+	users, err := u.userRepo.FindByIDs(ctx, unique)
+	if err != nil {
 		return nil, err
 	}
 
-	return &dto.UserResponse{
-		ID:        user.ID,
-		Name:      user.Name,
-		Email:     user.Email,
-		Role:      user.Role,
-		IsActive:  user.IsActive,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
-	}, nil
+	byID := make(map[uint]*entity.User, len(users))
+	for i := range users {
+		byID[users[i].ID] = &users[i]
+	}
+
+	result := &dto.BatchGetUsersResponse{
+		Users:    make([]dto.UserResponse, 0, len(users)),
+		NotFound: []uint{},
+	}
+	for _, id := range unique {
+		user, ok := byID[id]
+		if !ok {
+			result.NotFound = append(result.NotFound, id)
+			continue
+		}
+		result.Users = append(result.Users, toUserResponse(user))
+	}
+	return result, nil
 }
 
-// GetAll gets all users with pagination
-func (u *userUseCase) GetAll(ctx context.Context, page, limit int) ([]dto.UserResponse, int64, error) {
-	users, total, err := u.userRepo.FindAll(ctx, page, limit)
+// GetAll gets all users matching the filter with pagination, counting them
+// as mode asks
+func (u *userUseCase) GetAll(ctx context.Context, filter *dto.UserFilterRequest, page, limit int, mode pagination.TotalMode) ([]dto.UserResponse, int64, error) {
+	if err := u.authorizeRead(ctx); err != nil {
+		return nil, 0, err
+	}
+
+	users, total, err := u.userRepo.FindAll(ctx, toUserFilter(filter), page, limit, mode)
 	if err != nil {
 		return nil, 0, err
 	}
 
 	var response []dto.UserResponse
 	for _, user := range users {
-		response = append(response, dto.UserResponse{
-			ID:        user.ID,
-			Name:      user.Name,
-			Email:     user.Email,
-			Role:      user.Role,
-			IsActive:  user.IsActive,
-			CreatedAt: user.CreatedAt,
-			UpdatedAt: user.UpdatedAt,
-		})
+		response = append(response, toUserResponse(&user))
 	}
 
 	return response, total, nil
 }
 
-// Update updates a user
+// exportBatchSize is the number of users loaded per batch during export
+const exportBatchSize = 500
+
+// Search finds users whose name or email contains the query, most relevant
+// first
+func (u *userUseCase) Search(ctx context.Context, req *dto.UserSearchRequest, page, limit int) ([]dto.UserResponse, int64, error) {
+	query := strings.TrimSpace(req.Q)
+	if query == "" {
+		return nil, 0, apperrors.NewAppError(http.StatusBadRequest, apperrors.SlugBadRequest, "Search query is required", nil)
+	}
+
+	filter := repository.UserFilter{Search: query, IncludeDeleted: req.IncludeDeleted}
+	users, total, err := u.userRepo.Search(ctx, filter, page, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	response := make([]dto.UserResponse, 0, len(users))
+	for i := range users {
+		response = append(response, toUserResponse(&users[i]))
+	}
+	return response, total, nil
+}
+
+// Export calls fn with successive batches of users matching the filter
+func (u *userUseCase) Export(ctx context.Context, req *dto.UserExportRequest, fn func([]dto.UserResponse) error) error {
+	filter := toUserFilter(&req.UserFilterRequest)
+	filter.IncludeDeleted = req.IncludeDeleted
+
+	err := u.userRepo.FindInBatches(ctx, filter, exportBatchSize, func(users []entity.User) error {
+		batch := make([]dto.UserResponse, 0, len(users))
+		for i := range users {
+			batch = append(batch, toUserResponse(&users[i]))
+		}
+		return fn(batch)
+	})
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Update replaces a user's profile. Users may only update their own account
+// unless they are an admin.
 func (u *userUseCase) Update(ctx context.Context, id uint, req *dto.UpdateUserRequest) (*dto.UserResponse, error) {
-	user, err := u.userRepo.FindByID(ctx, id)
+	return u.updateFields(ctx, id, req.Version, func() (map[string]interface{}, error) {
+		return u.profileFields(ctx, id, req.Name, req.Email, req.Password)
+	})
+}
+
+// Patch changes the fields present in req, leaving the others unchanged. A
+// null avatar_url removes the avatar. Users may only update their own account
+// unless they are an admin.
+func (u *userUseCase) Patch(ctx context.Context, id uint, req *dto.PatchUserRequest) (*dto.UserResponse, error) {
+	if req.IsEmpty() {
+		return nil, apperrors.NewAppError(apperrors.ErrValidation.Code, apperrors.SlugValidation, "At least one field must be provided", nil)
+	}
+
+	resp, err := u.updateFields(ctx, id, req.Version, func() (map[string]interface{}, error) {
+		fields, err := u.profileFields(ctx, id, req.Name.Ptr(), req.Email.Ptr(), req.Password.Ptr())
+		if err != nil {
+			return nil, err
+		}
+		if req.AvatarURL.Null {
+			fields["avatar_url"] = ""
+		}
+		return fields, nil
+	})
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("user not found")
+		return nil, err
+	}
+
+	if req.AvatarURL.Null {
+		for _, ext := range avatarExtensions {
+			if err := u.storage.Delete(ctx, avatarKey(id, ext)); err != nil {
+				logger.Warnf("Failed to delete avatar for user %d: %v", id, err)
+			}
 		}
+	}
+	return resp, nil
+}
+
+// updateFields writes the columns returned by build to a user. When version
+// is set the update is rejected with apperrors.ErrVersionConflict unless it
+// matches the current version.
+func (u *userUseCase) updateFields(ctx context.Context, id uint, version *int, build func() (map[string]interface{}, error)) (*dto.UserResponse, error) {
+	if err := authorizeWrite(ctx, id); err != nil {
 		return nil, err
 	}
 
-	// Update fields
-	if req.Name != "" {
-		user.Name = req.Name
+	// Read from the primary, a replica may lag behind the current version
+	user, err := u.userRepo.FindByID(database.WithPrimary(ctx), id)
+	if err != nil {
+		return nil, err
+	}
+	if version != nil && *version != user.Version {
+		return nil, apperrors.ErrVersionConflict
 	}
-	if req.Email != "" {
+
+	fields, err := build()
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		resp := toUserResponse(user)
+		return &resp, nil
+	}
+
+	// The version read above guards against a concurrent update in between
+	fields["version"] = user.Version
+	if err := u.userRepo.UpdatePartial(ctx, id, fields); err != nil {
+		return nil, err
+	}
+	u.invalidateUser(ctx, id)
+
+	if user, err = u.userRepo.FindByID(database.WithPrimary(ctx), id); err != nil {
+		return nil, err
+	}
+
+	resp := toUserResponse(user)
+	u.publishUserUpdated(ctx, &resp)
+	return &resp, nil
+}
+
+// profileFields maps the profile fields that are set to the columns to write
+func (u *userUseCase) profileFields(ctx context.Context, id uint, name, email, password *string) (map[string]interface{}, error) {
+	fields := make(map[string]interface{})
+
+	if name != nil {
+		fields["name"] = *name
+	}
+	if email != nil {
 		// Check if email is already taken by another user
-		existingUser, err := u.userRepo.FindByEmail(ctx, req.Email)
-		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		existingUser, err := u.userRepo.FindByEmail(database.WithPrimary(ctx), *email)
+		if err != nil && !errors.Is(err, apperrors.ErrUserNotFound) {
 			return nil, err
 		}
 		if existingUser != nil && existingUser.ID != id {
-			return nil, errors.New("email already taken")
+			return nil, apperrors.ErrEmailTaken
 		}
-		user.Email = req.Email
+		fields["email"] = *email
 	}
-	if req.Password != "" {
-		hashedPassword, err := utils.HashPassword(req.Password)
+	if password != nil {
+		hashedPassword, err := utils.HashPassword(*password)
 		if err != nil {
 			return nil, err
 		}
-		user.Password = hashedPassword
+		fields["password"] = hashedPassword
 	}
 
-	if err := u.userRepo.Update(ctx, user); err != nil {
+	return fields, nil
+}
+
+// Delete deletes a user. Users may only delete their own account unless they
+// are an admin.
+func (u *userUseCase) Delete(ctx context.Context, id uint) error {
+	if err := authorizeWrite(ctx, id); err != nil {
+		return err
+	}
+
+	_, err := u.userRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := u.userRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+	u.invalidateUser(ctx, id)
+
+	u.events.Publish(ctx, UserDeleted{UserID: id})
+
+	return nil
+}
+
+// DeleteByIDs deletes the users with the given IDs in one transaction, softly
+// or not as DELETE_MODE says, on behalf of an admin. Duplicate IDs are
+// collapsed and the outcome for each is reported in the order first
+// requested. The admin's own account and the last active admin cannot be
+// deleted this way; either rejects the whole batch, see
+// repository.UserRepository.DeleteByIDs.
+func (u *userUseCase) DeleteByIDs(ctx context.Context, ids []uint) (*dto.BatchDeleteUsersResponse, error) {
+	actor, ok := auth.CurrentUser(ctx)
+	if !ok {
+		return nil, apperrors.ErrUnauthorized
+	}
+
+	unique := make([]uint, 0, len(ids))
+	seen := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		if id == actor.ID {
+			return nil, apperrors.ErrSelfDelete
+		}
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	if len(unique) > constants.MaxBatchIDs {
+		return nil, apperrors.NewAppError(http.StatusBadRequest, apperrors.SlugBadRequest,
+			fmt.Sprintf("At most %d IDs can be deleted at once", constants.MaxBatchIDs), nil)
+	}
+
+	deleted, err := u.userRepo.DeleteByIDs(ctx, unique)
+	if err != nil {
 		return nil, err
 	}
 
-	return &dto.UserResponse{
-		ID:        user.ID,
-		Name:      user.Name,
-		Email:     user.Email,
-		Role:      user.Role,
-		IsActive:  user.IsActive,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
-	}, nil
+	wasDeleted := make(map[uint]bool, len(deleted))
+	for _, id := range deleted {
+		wasDeleted[id] = true
+		u.invalidateUser(ctx, id)
+		u.events.Publish(ctx, UserDeleted{UserID: id})
+	}
+
+	result := &dto.BatchDeleteUsersResponse{
+		Results: make([]dto.BatchDeleteResult, 0, len(unique)),
+		Deleted: len(deleted),
+	}
+	for _, id := range unique {
+		status := dto.BatchDeleteNotFound
+		if wasDeleted[id] {
+			status = dto.BatchDeleteDeleted
+		}
+		result.Results = append(result.Results, dto.BatchDeleteResult{ID: id, Status: status})
+	}
+	return result, nil
 }
 
-// Delete deletes a user
-func (u *userUseCase) Delete(ctx context.Context, id uint) error {
-	_, err := u.userRepo.FindByID(ctx, id)
+// GetByIDWithDeleted gets a user by ID, including soft-deleted users
+func (u *userUseCase) GetByIDWithDeleted(ctx context.Context, id uint) (*dto.UserResponse, error) {
+	user, err := u.userRepo.FindByIDWithDeleted(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := toUserResponse(user)
+	return &resp, nil
+}
+
+// Restore restores a soft-deleted user. Users that do not exist or were never
+// deleted are reported as not found.
+func (u *userUseCase) Restore(ctx context.Context, id uint) (*dto.UserResponse, error) {
+	if err := u.userRepo.Restore(ctx, id); err != nil {
+		return nil, err
+	}
+	u.invalidateUser(ctx, id)
+
+	u.auditUseCase.Record(ctx, AuditEntry{
+		Action:     AuditActionRestore,
+		TargetType: AuditTargetUser,
+		TargetID:   id,
+	})
+
+	// Read from the primary, a replica may not have seen the restore yet
+	resp, err := u.GetByID(database.WithPrimary(ctx), id)
+	if err != nil {
+		return nil, err
+	}
+	u.publishUserUpdated(ctx, resp)
+	return resp, nil
+}
+
+// ChangeRole gives a user another role. The last active admin cannot be
+// demoted, so the application always keeps an admin. The role is embedded in
+// issued tokens, so the user's tokens are revoked.
+func (u *userUseCase) ChangeRole(ctx context.Context, id uint, req *dto.ChangeRoleRequest) (*dto.UserResponse, error) {
+	// Read from the primary, a replica may not have seen a recent change
+	user, err := u.userRepo.FindByID(database.WithPrimary(ctx), id)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role == req.Role {
+		resp := toUserResponse(user)
+		return &resp, nil
+	}
+
+	if user.Role == constants.RoleAdmin && user.IsActive() {
+		admins, err := u.userRepo.CountByFilter(database.WithPrimary(ctx), repository.UserFilter{
+			Role:   constants.RoleAdmin,
+			Status: constants.UserStatusActive,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if admins <= 1 {
+			return nil, apperrors.ErrLastAdmin
+		}
+	}
+
+	resp, err := u.updateFields(ctx, id, &user.Version, func() (map[string]interface{}, error) {
+		return map[string]interface{}{"role": req.Role}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.userRepo.IncrementTokenVersion(ctx, id); err != nil {
+		return nil, err
+	}
+	u.invalidateUser(ctx, id)
+
+	u.auditUseCase.Record(ctx, AuditEntry{
+		Action:     AuditActionRoleChange,
+		TargetType: AuditTargetUser,
+		TargetID:   id,
+		Metadata:   map[string]interface{}{"from": user.Role, "to": req.Role},
+	})
+
+	return resp, nil
+}
+
+// ChangeStatus moves a user to another status. Admins cannot change their own
+// status, so they cannot lock themselves out. Leaving the active status
+// revokes the user's tokens, ending their sessions.
+func (u *userUseCase) ChangeStatus(ctx context.Context, id uint, req *dto.ChangeStatusRequest) (*dto.UserResponse, error) {
+	return u.setStatus(ctx, id, req.Status, req.Status != constants.UserStatusActive)
+}
+
+// Activate lets a user log in again
+func (u *userUseCase) Activate(ctx context.Context, id uint) (*dto.UserResponse, error) {
+	return u.setStatus(ctx, id, constants.UserStatusActive, false)
+}
+
+// Deactivate suspends a user without deleting them, so they can no longer log
+// in. Their tokens are revoked too unless req.RevokeTokens is false, in which
+// case existing sessions last until their tokens expire.
+func (u *userUseCase) Deactivate(ctx context.Context, id uint, req *dto.DeactivateUserRequest) (*dto.UserResponse, error) {
+	revoke := req.RevokeTokens == nil || *req.RevokeTokens
+	return u.setStatus(ctx, id, constants.UserStatusSuspended, revoke)
+}
+
+// setStatus writes a user's status, revoking their tokens if asked to.
+// Admins cannot change their own status, so they cannot lock themselves out.
+func (u *userUseCase) setStatus(ctx context.Context, id uint, status string, revokeTokens bool) (*dto.UserResponse, error) {
+	if actor, ok := auth.CurrentUser(ctx); ok && actor.ID == id {
+		return nil, apperrors.ErrSelfStatusChange
+	}
+
+	resp, err := u.updateFields(ctx, id, nil, func() (map[string]interface{}, error) {
+		return map[string]interface{}{"status": status}, nil
+	})
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("user not found")
+		return nil, err
+	}
+
+	if revokeTokens {
+		if err := u.userRepo.IncrementTokenVersion(ctx, id); err != nil {
+			return nil, err
 		}
+		u.invalidateUser(ctx, id)
+	}
+
+	u.auditUseCase.Record(ctx, AuditEntry{
+		Action:     AuditActionStatusChange,
+		TargetType: AuditTargetUser,
+		TargetID:   id,
+		Metadata:   map[string]interface{}{"status": status, "tokens_revoked": revokeTokens},
+	})
+
+	return resp, nil
+}
+
+// statusError returns the error explaining why a user with the given status
+// may not log in, or nil for active users
+func statusError(status string) error {
+	switch status {
+	case constants.UserStatusActive:
+		return nil
+	case constants.UserStatusPending:
+		return apperrors.ErrUserPending
+	case constants.UserStatusSuspended:
+		return apperrors.ErrUserSuspended
+	case constants.UserStatusBanned:
+		return apperrors.ErrUserBanned
+	default:
+		return apperrors.ErrUserNotActive
+	}
+}
+
+// Purge permanently deletes a user. Only admins may purge, and the request must
+// confirm the email of the user being purged. Every purge is recorded in the audit log.
+func (u *userUseCase) Purge(ctx context.Context, id, actorID uint, actorRole string, req *dto.PurgeUserRequest) error {
+	if actorRole != constants.RoleAdmin {
+		return apperrors.ErrForbidden
+	}
+
+	user, err := u.userRepo.FindByIDWithDeleted(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if !strings.EqualFold(user.Email, req.ConfirmEmail) {
+		return apperrors.ErrConfirmMismatch
+	}
+
+	if err := u.userRepo.PurgeByID(ctx, id); err != nil {
 		return err
 	}
+	u.invalidateUser(ctx, id)
+
+	u.auditUseCase.Record(ctx, AuditEntry{
+		ActorID:    actorID,
+		Action:     AuditActionPurge,
+		TargetType: AuditTargetUser,
+		TargetID:   id,
+	})
 
-	return u.userRepo.Delete(ctx, id)
+	return nil
+}
+
+// PurgeDeleted permanently deletes the users soft-deleted more than olderThan
+// ago. It runs as a scheduled job rather than on behalf of a user.
+func (u *userUseCase) PurgeDeleted(ctx context.Context, olderThan time.Duration) error {
+	purged, err := u.userRepo.PurgeDeletedBefore(ctx, time.Now().Add(-olderThan))
+	if err != nil {
+		return err
+	}
+	if purged > 0 {
+		logger.Infof("Purged %d users deleted more than %s ago", purged, olderThan)
+	}
+	return nil
+}
+
+// rehashPassword replaces the user's password hash with one using the current
+// cost. Failures are only logged, the old hash remains valid.
+func (u *userUseCase) rehashPassword(ctx context.Context, user *entity.User, password string) {
+	hashedPassword, err := utils.HashPassword(password)
+	if err != nil {
+		logger.Warnf("Failed to rehash password for user %d: %v", user.ID, err)
+		return
+	}
+
+	user.Password = hashedPassword
+	if err := u.userRepo.Update(ctx, user); err != nil {
+		logger.Warnf("Failed to save rehashed password for user %d: %v", user.ID, err)
+	}
+}
+
+// authorizeWrite checks that the caller may modify the user with the given
+// ID: their own account, or any account for admins
+func authorizeWrite(ctx context.Context, id uint) error {
+	caller, ok := auth.CurrentUser(ctx)
+	if !ok {
+		return apperrors.ErrUnauthorized
+	}
+	if !caller.CanAccess(id) {
+		return apperrors.ErrForbidden
+	}
+	return nil
+}
+
+// authorizeRead checks that the caller may read the users with the given IDs.
+// Reads are open unless USERS_READ_OWN_ONLY is set, which limits non-admins to
+// their own account; listing users without IDs then requires admin.
+func (u *userUseCase) authorizeRead(ctx context.Context, ids ...uint) error {
+	if !u.usersCfg.ReadOwnOnly {
+		return nil
+	}
+
+	caller, ok := auth.CurrentUser(ctx)
+	if !ok {
+		return apperrors.ErrUnauthorized
+	}
+	if caller.IsAdmin() {
+		return nil
+	}
+	if len(ids) == 0 {
+		return apperrors.ErrForbidden
+	}
+	for _, id := range ids {
+		if id != caller.ID {
+			return apperrors.ErrForbidden
+		}
+	}
+	return nil
+}
+
+// userCacheKey returns the cache key of a user
+func userCacheKey(id uint) string {
+	return fmt.Sprintf("user:%d", id)
+}
+
+// invalidateUser drops the cached user after a change. Failures are only
+// logged, the entries then expire with their TTL.
+func (u *userUseCase) invalidateUser(ctx context.Context, id uint) {
+	if err := u.cache.Delete(ctx, userCacheKey(id)); err != nil {
+		logger.Warnf("Failed to invalidate cached user %d: %v", id, err)
+	}
+}
+
+// publishUserUpdated announces a change of the user, which RegisterSubscribers
+// forwards to their open event streams
+func (u *userUseCase) publishUserUpdated(ctx context.Context, user *dto.UserResponse) {
+	u.events.Publish(ctx, UserUpdated{User: *user})
+}
+
+// toUserFilter maps the filter request to a repository filter
+func toUserFilter(req *dto.UserFilterRequest) repository.UserFilter {
+	if req == nil {
+		return repository.UserFilter{}
+	}
+	return repository.UserFilter{
+		Role:     req.Role,
+		Status:   req.Status,
+		IsActive: req.IsActive,
+		Search:   strings.TrimSpace(req.Search),
+	}
+}
+
+// toUserResponse maps a user entity to its response DTO
+func toUserResponse(user *entity.User) dto.UserResponse {
+	resp := dto.UserResponse{
+		ID:        user.ID,
+		Name:      user.Name,
+		Email:     user.Email,
+		Role:      user.Role,
+		Status:    user.Status,
+		IsActive:  user.IsActive(),
+		AvatarURL: user.AvatarURL,
+		Version:   user.Version,
+		CreatedAt: dto.NewTimestamp(user.CreatedAt),
+		UpdatedAt: dto.NewTimestamp(user.UpdatedAt),
+	}
+	if user.DeletedAt.Valid {
+		resp.DeletedAt = dto.NewTimestampPtr(user.DeletedAt.Time)
+	}
+	return resp
 }
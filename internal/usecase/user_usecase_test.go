@@ -0,0 +1,275 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/your-username/go-clean-architecture/config"
+	"github.com/your-username/go-clean-architecture/internal/dto"
+	"github.com/your-username/go-clean-architecture/internal/entity"
+	"github.com/your-username/go-clean-architecture/internal/repository"
+	"github.com/your-username/go-clean-architecture/pkg/apperrors"
+	"github.com/your-username/go-clean-architecture/pkg/auth"
+	"github.com/your-username/go-clean-architecture/pkg/cache"
+	"github.com/your-username/go-clean-architecture/pkg/constants"
+	"github.com/your-username/go-clean-architecture/pkg/events"
+	"github.com/your-username/go-clean-architecture/pkg/logger"
+	"github.com/your-username/go-clean-architecture/pkg/pagination"
+)
+
+func TestMain(m *testing.M) {
+	logger.InitLogger(false)
+	os.Exit(m.Run())
+}
+
+// fakeUserRepo keeps users in memory. Methods the tests do not need are left
+// to the embedded nil interface and panic if called.
+type fakeUserRepo struct {
+	repository.UserRepository
+	users map[uint]*entity.User
+}
+
+func newFakeUserRepo(users ...entity.User) *fakeUserRepo {
+	r := &fakeUserRepo{users: make(map[uint]*entity.User, len(users))}
+	for i := range users {
+		r.users[users[i].ID] = &users[i]
+	}
+	return r
+}
+
+func (r *fakeUserRepo) FindByID(_ context.Context, id uint) (*entity.User, error) {
+	user, ok := r.users[id]
+	if !ok {
+		return nil, apperrors.ErrUserNotFound
+	}
+	found := *user
+	return &found, nil
+}
+
+func (r *fakeUserRepo) FindByIDs(_ context.Context, ids []uint) ([]entity.User, error) {
+	var users []entity.User
+	for _, id := range ids {
+		if user, ok := r.users[id]; ok {
+			users = append(users, *user)
+		}
+	}
+	return users, nil
+}
+
+func (r *fakeUserRepo) FindAll(context.Context, repository.UserFilter, int, int, pagination.TotalMode) ([]entity.User, int64, error) {
+	users := make([]entity.User, 0, len(r.users))
+	for _, user := range r.users {
+		users = append(users, *user)
+	}
+	return users, int64(len(users)), nil
+}
+
+func (r *fakeUserRepo) CountByFilter(_ context.Context, filter repository.UserFilter) (int64, error) {
+	var n int64
+	for _, user := range r.users {
+		if (filter.Role == "" || user.Role == filter.Role) && (filter.Status == "" || user.Status == filter.Status) {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (r *fakeUserRepo) UpdatePartial(_ context.Context, id uint, fields map[string]interface{}) error {
+	user, ok := r.users[id]
+	if !ok {
+		return apperrors.ErrUserNotFound
+	}
+	if name, ok := fields["name"].(string); ok {
+		user.Name = name
+	}
+	user.Version++
+	return nil
+}
+
+func (r *fakeUserRepo) Delete(_ context.Context, id uint) error {
+	delete(r.users, id)
+	return nil
+}
+
+func (r *fakeUserRepo) DeleteByIDs(_ context.Context, ids []uint) ([]uint, error) {
+	var deleted []uint
+	for _, id := range ids {
+		if _, ok := r.users[id]; ok {
+			delete(r.users, id)
+			deleted = append(deleted, id)
+		}
+	}
+	return deleted, nil
+}
+
+// Accounts of the authorization tests
+var (
+	testAdmin = entity.User{ID: 1, Name: "Admin", Email: "admin@example.com", Role: constants.RoleAdmin, Status: constants.UserStatusActive}
+	testJane  = entity.User{ID: 2, Name: "Jane", Email: "jane@example.com", Role: constants.RoleUser, Status: constants.UserStatusActive}
+	testJohn  = entity.User{ID: 3, Name: "John", Email: "john@example.com", Role: constants.RoleUser, Status: constants.UserStatusActive}
+)
+
+// newTestUserUseCase returns a use case over a fake repository holding an
+// admin, Jane and John
+func newTestUserUseCase(usersCfg config.UsersConfig) *userUseCase {
+	repo := newFakeUserRepo(testAdmin, testJane, testJohn)
+	return NewUserUseCase(repo, nil, nil, nil, config.AvatarConfig{}, usersCfg, cache.NewMemory(100), 0, events.NewBus()).(*userUseCase)
+}
+
+// asUser returns a context authenticated as user, or an anonymous one for nil
+func asUser(user *entity.User) context.Context {
+	ctx := context.Background()
+	if user == nil {
+		return ctx
+	}
+	return auth.WithUser(ctx, &auth.AuthUser{ID: user.ID, Email: user.Email, Role: user.Role})
+}
+
+// authorizationCases are the callers acting on Jane's account
+var authorizationCases = []struct {
+	name   string
+	caller *entity.User
+	want   error
+}{
+	{name: "self", caller: &testJane},
+	{name: "another user", caller: &testJohn, want: apperrors.ErrForbidden},
+	{name: "admin", caller: &testAdmin},
+	{name: "unauthenticated", want: apperrors.ErrUnauthorized},
+}
+
+func TestUserUseCasePatchAuthorization(t *testing.T) {
+	name := "Janet"
+	for _, tt := range authorizationCases {
+		t.Run(tt.name, func(t *testing.T) {
+			u := newTestUserUseCase(config.UsersConfig{})
+			_, err := u.Patch(asUser(tt.caller), testJane.ID, &dto.PatchUserRequest{Name: dto.Nullable[string]{Set: true, Value: name}})
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Patch() error = %v, want %v", err, tt.want)
+			}
+
+			got := u.userRepo.(*fakeUserRepo).users[testJane.ID].Name
+			if changed := got == name; changed != (tt.want == nil) {
+				t.Errorf("name = %q after Patch() returned %v", got, err)
+			}
+		})
+	}
+}
+
+func TestUserUseCaseDeleteAuthorization(t *testing.T) {
+	for _, tt := range authorizationCases {
+		t.Run(tt.name, func(t *testing.T) {
+			u := newTestUserUseCase(config.UsersConfig{})
+			err := u.Delete(asUser(tt.caller), testJane.ID)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Delete() error = %v, want %v", err, tt.want)
+			}
+
+			_, exists := u.userRepo.(*fakeUserRepo).users[testJane.ID]
+			if exists != (tt.want != nil) {
+				t.Errorf("user exists = %v after Delete() returned %v", exists, err)
+			}
+		})
+	}
+}
+
+func TestUserUseCaseGetByIDAuthorization(t *testing.T) {
+	t.Run("read own only", func(t *testing.T) {
+		for _, tt := range authorizationCases {
+			t.Run(tt.name, func(t *testing.T) {
+				u := newTestUserUseCase(config.UsersConfig{ReadOwnOnly: true})
+				_, err := u.GetByID(asUser(tt.caller), testJane.ID)
+				if !errors.Is(err, tt.want) {
+					t.Fatalf("GetByID() error = %v, want %v", err, tt.want)
+				}
+			})
+		}
+	})
+
+	t.Run("open reads", func(t *testing.T) {
+		u := newTestUserUseCase(config.UsersConfig{})
+		resp, err := u.GetByID(asUser(&testJohn), testJane.ID)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if resp.ID != testJane.ID {
+			t.Errorf("GetByID() returned user %d, want %d", resp.ID, testJane.ID)
+		}
+	})
+}
+
+func TestUserUseCaseGetByIDsAuthorization(t *testing.T) {
+	tests := []struct {
+		name   string
+		caller *entity.User
+		ids    []uint
+		want   error
+	}{
+		{name: "self", caller: &testJane, ids: []uint{testJane.ID, testJane.ID}},
+		{name: "self and another user", caller: &testJane, ids: []uint{testJane.ID, testJohn.ID}, want: apperrors.ErrForbidden},
+		{name: "admin", caller: &testAdmin, ids: []uint{testJane.ID, testJohn.ID}},
+		{name: "unauthenticated", ids: []uint{testJane.ID}, want: apperrors.ErrUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := newTestUserUseCase(config.UsersConfig{ReadOwnOnly: true})
+			_, err := u.GetByIDs(asUser(tt.caller), tt.ids)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("GetByIDs() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserUseCaseGetAllAuthorization(t *testing.T) {
+	tests := []struct {
+		name        string
+		readOwnOnly bool
+		caller      *entity.User
+		want        error
+	}{
+		{name: "user with open reads", caller: &testJane},
+		{name: "user with read own only", readOwnOnly: true, caller: &testJane, want: apperrors.ErrForbidden},
+		{name: "admin with read own only", readOwnOnly: true, caller: &testAdmin},
+		{name: "unauthenticated with read own only", readOwnOnly: true, want: apperrors.ErrUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := newTestUserUseCase(config.UsersConfig{ReadOwnOnly: tt.readOwnOnly})
+			_, _, err := u.GetAll(asUser(tt.caller), nil, 1, 10, pagination.TotalExact)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("GetAll() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserUseCaseDeleteByIDs(t *testing.T) {
+	tests := []struct {
+		name        string
+		caller      *entity.User
+		ids         []uint
+		want        error
+		wantDeleted int
+	}{
+		{name: "admin deletes users", caller: &testAdmin, ids: []uint{testJane.ID, testJohn.ID, 99}, wantDeleted: 2},
+		{name: "admin in the batch", caller: &testAdmin, ids: []uint{testJane.ID, testAdmin.ID}, want: apperrors.ErrSelfDelete},
+		{name: "unauthenticated", ids: []uint{testJane.ID}, want: apperrors.ErrUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := newTestUserUseCase(config.UsersConfig{})
+			resp, err := u.DeleteByIDs(asUser(tt.caller), tt.ids)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("DeleteByIDs() error = %v, want %v", err, tt.want)
+			}
+			if err == nil && resp.Deleted != tt.wantDeleted {
+				t.Errorf("DeleteByIDs() deleted %d users, want %d", resp.Deleted, tt.wantDeleted)
+			}
+		})
+	}
+}
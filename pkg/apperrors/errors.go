@@ -4,13 +4,36 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"runtime"
+	"strings"
+	"sync/atomic"
 )
 
-// AppError represents an application error
+// maxStackDepth limits the number of frames captured per error
+const maxStackDepth = 32
+
+// captureStacks controls whether NewAppError and WrapError record a stack trace
+var captureStacks atomic.Bool
+
+func init() {
+	captureStacks.Store(true)
+}
+
+// SetStackCapture enables or disables stack trace capture for new errors
+func SetStackCapture(enabled bool) {
+	captureStacks.Store(enabled)
+}
+
+// AppError represents an application error. Code is the HTTP status code and
+// Slug is a stable machine-readable identifier clients can branch on.
 type AppError struct {
 	Code    int    `json:"code"`
+	Slug    string `json:"slug"`
 	Message string `json:"message"`
 	Err     error  `json:"-"`
+
+	// stack holds the program counters where the error was created
+	stack []uintptr
 }
 
 // Error implements the error interface
@@ -26,26 +49,135 @@ func (e *AppError) Unwrap() error {
 	return e.Err
 }
 
+// Is reports whether target is an AppError with the same slug, so wrapped
+// errors still match the sentinel they were made from, e.g.
+// errors.Is(WrapError(ErrUserNotFound, err), ErrUserNotFound)
+func (e *AppError) Is(target error) bool {
+	t, ok := target.(*AppError)
+	return ok && t.Slug == e.Slug
+}
+
+// StackTrace returns the captured stack trace, one "function\n\tfile:line"
+// entry per frame, or an empty string when no stack was captured.
+func (e *AppError) StackTrace() string {
+	var sb strings.Builder
+	for _, frame := range e.frames(0) {
+		fmt.Fprintf(&sb, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+	}
+	return sb.String()
+}
+
+// StackFrames returns up to limit frames of the captured stack formatted as
+// "function file:line". A limit of zero or less returns every frame.
+func (e *AppError) StackFrames(limit int) []string {
+	frames := e.frames(limit)
+	lines := make([]string, 0, len(frames))
+	for _, frame := range frames {
+		lines = append(lines, fmt.Sprintf("%s %s:%d", frame.Function, frame.File, frame.Line))
+	}
+	return lines
+}
+
+// frames resolves up to limit captured program counters
+func (e *AppError) frames(limit int) []runtime.Frame {
+	if len(e.stack) == 0 {
+		return nil
+	}
+
+	var frames []runtime.Frame
+	iter := runtime.CallersFrames(e.stack)
+	for {
+		frame, more := iter.Next()
+		frames = append(frames, frame)
+		if !more || (limit > 0 && len(frames) >= limit) {
+			return frames
+		}
+	}
+}
+
+// callers captures the stack of the caller of NewAppError or WrapError
+func callers() []uintptr {
+	if !captureStacks.Load() {
+		return nil
+	}
+
+	pcs := make([]uintptr, maxStackDepth)
+	n := runtime.Callers(3, pcs)
+	return pcs[:n]
+}
+
+// Error slugs
+const (
+	SlugNotFound          = "NOT_FOUND"
+	SlugBadRequest        = "BAD_REQUEST"
+	SlugUnauthorized      = "UNAUTHORIZED"
+	SlugForbidden         = "FORBIDDEN"
+	SlugConflict          = "CONFLICT"
+	SlugInternalServer    = "INTERNAL_SERVER_ERROR"
+	SlugValidation        = "VALIDATION_ERROR"
+	SlugInvalidCredential = "INVALID_CREDENTIALS"
+	SlugUserNotActive     = "USER_NOT_ACTIVE"
+	SlugUserPending       = "USER_PENDING"
+	SlugUserSuspended     = "USER_SUSPENDED"
+	SlugUserBanned        = "USER_BANNED"
+	SlugSelfStatusChange  = "SELF_STATUS_CHANGE"
+	SlugLastAdmin         = "LAST_ADMIN"
+	SlugEmailTaken        = "EMAIL_TAKEN"
+	SlugUserNotFound      = "USER_NOT_FOUND"
+	SlugConfirmMismatch   = "CONFIRMATION_MISMATCH"
+	SlugIdempotencyBusy   = "IDEMPOTENCY_KEY_IN_USE"
+	SlugIdempotencyReuse  = "IDEMPOTENCY_KEY_REUSED"
+	SlugPayloadTooLarge   = "PAYLOAD_TOO_LARGE"
+	SlugInvalidImage      = "INVALID_IMAGE"
+	SlugQueryTimeout      = "QUERY_TIMEOUT"
+	SlugTokenRevoked      = "TOKEN_REVOKED"
+	SlugVersionConflict   = "VERSION_CONFLICT"
+	SlugReauthRequired    = "REAUTHENTICATION_REQUIRED"
+	SlugShuttingDown      = "SHUTTING_DOWN"
+	SlugMalformedBody     = "MALFORMED_BODY"
+	SlugSelfDelete        = "SELF_DELETE"
+)
+
 // Common errors
 var (
-	ErrNotFound          = &AppError{Code: http.StatusNotFound, Message: "Resource not found"}
-	ErrBadRequest        = &AppError{Code: http.StatusBadRequest, Message: "Bad request"}
-	ErrUnauthorized      = &AppError{Code: http.StatusUnauthorized, Message: "Unauthorized"}
-	ErrForbidden         = &AppError{Code: http.StatusForbidden, Message: "Forbidden"}
-	ErrConflict          = &AppError{Code: http.StatusConflict, Message: "Resource conflict"}
-	ErrInternalServer    = &AppError{Code: http.StatusInternalServerError, Message: "Internal server error"}
-	ErrValidation        = &AppError{Code: http.StatusUnprocessableEntity, Message: "Validation error"}
-	ErrInvalidCredential = &AppError{Code: http.StatusUnauthorized, Message: "Invalid email or password"}
-	ErrUserNotActive     = &AppError{Code: http.StatusForbidden, Message: "User account is not active"}
-	ErrEmailTaken        = &AppError{Code: http.StatusConflict, Message: "Email is already registered"}
+	ErrNotFound          = &AppError{Code: http.StatusNotFound, Slug: SlugNotFound, Message: "Resource not found"}
+	ErrBadRequest        = &AppError{Code: http.StatusBadRequest, Slug: SlugBadRequest, Message: "Bad request"}
+	ErrUnauthorized      = &AppError{Code: http.StatusUnauthorized, Slug: SlugUnauthorized, Message: "Unauthorized"}
+	ErrForbidden         = &AppError{Code: http.StatusForbidden, Slug: SlugForbidden, Message: "Forbidden"}
+	ErrConflict          = &AppError{Code: http.StatusConflict, Slug: SlugConflict, Message: "Resource conflict"}
+	ErrInternalServer    = &AppError{Code: http.StatusInternalServerError, Slug: SlugInternalServer, Message: "Internal server error"}
+	ErrValidation        = &AppError{Code: http.StatusUnprocessableEntity, Slug: SlugValidation, Message: "Validation error"}
+	ErrInvalidCredential = &AppError{Code: http.StatusUnauthorized, Slug: SlugInvalidCredential, Message: "Invalid email or password"}
+	ErrUserNotActive     = &AppError{Code: http.StatusForbidden, Slug: SlugUserNotActive, Message: "User account is not active"}
+	ErrUserPending       = &AppError{Code: http.StatusForbidden, Slug: SlugUserPending, Message: "User account is pending verification"}
+	ErrUserSuspended     = &AppError{Code: http.StatusForbidden, Slug: SlugUserSuspended, Message: "User account is suspended"}
+	ErrUserBanned        = &AppError{Code: http.StatusForbidden, Slug: SlugUserBanned, Message: "User account is banned"}
+	ErrSelfStatusChange  = &AppError{Code: http.StatusForbidden, Slug: SlugSelfStatusChange, Message: "You cannot change the status of your own account"}
+	ErrLastAdmin         = &AppError{Code: http.StatusConflict, Slug: SlugLastAdmin, Message: "The last active admin cannot be demoted or deleted"}
+	ErrEmailTaken        = &AppError{Code: http.StatusConflict, Slug: SlugEmailTaken, Message: "Email is already registered"}
+	ErrUserNotFound      = &AppError{Code: http.StatusNotFound, Slug: SlugUserNotFound, Message: "User not found"}
+	ErrConfirmMismatch   = &AppError{Code: http.StatusBadRequest, Slug: SlugConfirmMismatch, Message: "Confirmation does not match"}
+	ErrIdempotencyBusy   = &AppError{Code: http.StatusConflict, Slug: SlugIdempotencyBusy, Message: "A request with this idempotency key is already in progress"}
+	ErrIdempotencyReuse  = &AppError{Code: http.StatusUnprocessableEntity, Slug: SlugIdempotencyReuse, Message: "Idempotency key was already used with a different request"}
+	ErrPayloadTooLarge   = &AppError{Code: http.StatusRequestEntityTooLarge, Slug: SlugPayloadTooLarge, Message: "Request body too large"}
+	ErrInvalidImage      = &AppError{Code: http.StatusUnprocessableEntity, Slug: SlugInvalidImage, Message: "File must be a JPEG, PNG or GIF image"}
+	ErrQueryTimeout      = &AppError{Code: http.StatusGatewayTimeout, Slug: SlugQueryTimeout, Message: "The database did not respond in time"}
+	ErrTokenRevoked      = &AppError{Code: http.StatusUnauthorized, Slug: SlugTokenRevoked, Message: "Token has been revoked"}
+	ErrVersionConflict   = &AppError{Code: http.StatusConflict, Slug: SlugVersionConflict, Message: "Resource was modified by another request, reload it and retry"}
+	ErrReauthRequired    = &AppError{Code: http.StatusUnauthorized, Slug: SlugReauthRequired, Message: "Re-authentication required, log in again to continue"}
+	ErrShuttingDown      = &AppError{Code: http.StatusServiceUnavailable, Slug: SlugShuttingDown, Message: "Service is shutting down, retry the request"}
+	ErrMalformedBody     = &AppError{Code: http.StatusBadRequest, Slug: SlugMalformedBody, Message: "Malformed request body"}
+	ErrSelfDelete        = &AppError{Code: http.StatusForbidden, Slug: SlugSelfDelete, Message: "You cannot delete your own account in a batch"}
 )
 
 // NewAppError creates a new AppError
-func NewAppError(code int, message string, err error) *AppError {
+func NewAppError(code int, slug, message string, err error) *AppError {
 	return &AppError{
 		Code:    code,
+		Slug:    slug,
 		Message: message,
 		Err:     err,
+		stack:   callers(),
 	}
 }
 
@@ -53,8 +185,10 @@ func NewAppError(code int, message string, err error) *AppError {
 func WrapError(appErr *AppError, err error) *AppError {
 	return &AppError{
 		Code:    appErr.Code,
+		Slug:    appErr.Slug,
 		Message: appErr.Message,
 		Err:     err,
+		stack:   callers(),
 	}
 }
 
@@ -0,0 +1,34 @@
+// Package audit carries request details needed by the audit trail through
+// context.Context, so use cases can record who did what without depending on HTTP.
+package audit
+
+import "context"
+
+type contextKey int
+
+const (
+	actorKey contextKey = iota
+	clientIPKey
+)
+
+// WithActor returns a copy of ctx carrying the authenticated user ID
+func WithActor(ctx context.Context, userID uint) context.Context {
+	return context.WithValue(ctx, actorKey, userID)
+}
+
+// ActorFromContext returns the authenticated user ID stored in ctx
+func ActorFromContext(ctx context.Context) (uint, bool) {
+	userID, ok := ctx.Value(actorKey).(uint)
+	return userID, ok
+}
+
+// WithClientIP returns a copy of ctx carrying the client IP address
+func WithClientIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, clientIPKey, ip)
+}
+
+// ClientIPFromContext returns the client IP address stored in ctx
+func ClientIPFromContext(ctx context.Context) string {
+	ip, _ := ctx.Value(clientIPKey).(string)
+	return ip
+}
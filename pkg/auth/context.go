@@ -0,0 +1,42 @@
+// Package auth carries the authenticated user through context.Context, so use
+// cases can make authorization decisions without depending on HTTP.
+package auth
+
+import (
+	"context"
+
+	"github.com/your-username/go-clean-architecture/pkg/constants"
+)
+
+type contextKey int
+
+const userKey contextKey = iota
+
+// AuthUser is the user a request is authenticated as
+type AuthUser struct {
+	ID    uint
+	Email string
+	Role  string
+}
+
+// IsAdmin reports whether the user has the admin role
+func (u *AuthUser) IsAdmin() bool {
+	return u.Role == constants.RoleAdmin
+}
+
+// CanAccess reports whether the user may act on the user with the given ID,
+// either because it is their own account or because they are an admin
+func (u *AuthUser) CanAccess(userID uint) bool {
+	return u.ID == userID || u.IsAdmin()
+}
+
+// WithUser returns a copy of ctx carrying the authenticated user
+func WithUser(ctx context.Context, user *AuthUser) context.Context {
+	return context.WithValue(ctx, userKey, user)
+}
+
+// CurrentUser returns the authenticated user stored in ctx
+func CurrentUser(ctx context.Context) (*AuthUser, bool) {
+	user, ok := ctx.Value(userKey).(*AuthUser)
+	return user, ok && user != nil
+}
@@ -0,0 +1,134 @@
+// Package cache implements cache-aside helpers backed by Redis or memory
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/your-username/go-clean-architecture/config"
+	"github.com/your-username/go-clean-architecture/pkg/database"
+	"github.com/your-username/go-clean-architecture/pkg/logger"
+	"golang.org/x/sync/singleflight"
+)
+
+// Cache drivers
+const (
+	DriverRedis  = "redis"
+	DriverMemory = "memory"
+)
+
+// redisKeyPrefix namespaces cache keys in Redis
+const redisKeyPrefix = "cache:"
+
+// ErrMiss is returned by Get when the key is not cached
+var ErrMiss = errors.New("cache: miss")
+
+// Cache stores JSON encoded values under string keys. Values are decoded into
+// the destination passed by the caller, so cached values are never shared.
+type Cache interface {
+	// Get decodes the value stored under key into dest, or returns ErrMiss
+	Get(ctx context.Context, key string, dest interface{}) error
+	// Set stores value under key for ttl, a ttl of zero never expires
+	Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error
+	// Delete removes the given keys
+	Delete(ctx context.Context, keys ...string) error
+	// Remember decodes the value stored under key into dest. On a miss it
+	// calls fetch, stores the result for ttl and decodes it into dest.
+	// Concurrent misses for the same key share a single fetch. Errors from
+	// fetch are returned unchanged and not cached.
+	Remember(ctx context.Context, key string, ttl time.Duration, dest interface{}, fetch func() (interface{}, error)) error
+}
+
+// NewCache creates the Cache selected by the CACHE_DRIVER configuration. The
+// Redis driver falls back to memory when Redis is unavailable.
+func NewCache(cfg *config.CacheConfig, client *database.RedisClient) (Cache, error) {
+	switch cfg.Driver {
+	case "", DriverRedis:
+		if client == nil {
+			logger.Warn("Redis unavailable, using in-memory cache")
+			return NewMemory(cfg.MemorySize), nil
+		}
+		return NewRedis(client, redisKeyPrefix), nil
+	case DriverMemory:
+		return NewMemory(cfg.MemorySize), nil
+	default:
+		return nil, fmt.Errorf("unknown cache driver: %s", cfg.Driver)
+	}
+}
+
+// store is the raw storage behind a cache
+type store interface {
+	get(ctx context.Context, key string) ([]byte, bool, error)
+	set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	delete(ctx context.Context, keys ...string) error
+}
+
+// cache implements Cache on top of a store
+type cache struct {
+	store store
+	group singleflight.Group
+}
+
+// Get implements Cache
+func (c *cache) Get(ctx context.Context, key string, dest interface{}) error {
+	data, ok, err := c.store.get(ctx, key)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrMiss
+	}
+	return json.Unmarshal(data, dest)
+}
+
+// Set implements Cache
+func (c *cache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return c.store.set(ctx, key, data, ttl)
+}
+
+// Delete implements Cache
+func (c *cache) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return c.store.delete(ctx, keys...)
+}
+
+// Remember implements Cache. The cache is best effort: when the store fails
+// the value is fetched directly and the failure only logged.
+func (c *cache) Remember(ctx context.Context, key string, ttl time.Duration, dest interface{}, fetch func() (interface{}, error)) error {
+	err := c.Get(ctx, key, dest)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, ErrMiss) {
+		logger.Warnf("Cache lookup failed for %s: %v", key, err)
+	}
+
+	// The first caller's fetch serves everyone waiting on the same key
+	data, err, _ := c.group.Do(key, func() (interface{}, error) {
+		value, err := fetch()
+		if err != nil {
+			return nil, err
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.store.set(ctx, key, data, ttl); err != nil {
+			logger.Warnf("Cache store failed for %s: %v", key, err)
+		}
+		return data, nil
+	})
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data.([]byte), dest)
+}
@@ -0,0 +1,45 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/your-username/go-clean-architecture/pkg/database"
+)
+
+// redisStore stores values in Redis under a common key prefix
+type redisStore struct {
+	client *database.RedisClient
+	prefix string
+}
+
+// NewRedis creates a cache backed by Redis. Keys are prefixed with prefix so
+// the cache can share a database with other data.
+func NewRedis(client *database.RedisClient, prefix string) Cache {
+	return &cache{store: &redisStore{client: client, prefix: prefix}}
+}
+
+func (s *redisStore) get(ctx context.Context, key string) ([]byte, bool, error) {
+	data, err := s.client.Get(ctx, s.prefix+key)
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return []byte(data), true, nil
+}
+
+func (s *redisStore) set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return s.client.Set(ctx, s.prefix+key, value, ttl)
+}
+
+func (s *redisStore) delete(ctx context.Context, keys ...string) error {
+	prefixed := make([]string, 0, len(keys))
+	for _, key := range keys {
+		prefixed = append(prefixed, s.prefix+key)
+	}
+	return s.client.Delete(ctx, prefixed...)
+}
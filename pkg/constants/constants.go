@@ -6,13 +6,24 @@ const (
 	RoleUser  = "user"
 )
 
-// Pagination defaults
+// User statuses. Only active users may log in.
+const (
+	UserStatusPending   = "pending"
+	UserStatusActive    = "active"
+	UserStatusSuspended = "suspended"
+	UserStatusBanned    = "banned"
+)
+
+// Pagination defaults, used when PAGINATION_* are not configured
 const (
 	DefaultPage  = 1
 	DefaultLimit = 10
 	MaxLimit     = 100
 )
 
+// MaxBatchIDs caps the IDs resolved by a single batch request
+const MaxBatchIDs = 100
+
 // Context keys
 const (
 	ContextKeyUserID    = "userID"
@@ -0,0 +1,112 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/your-username/go-clean-architecture/config"
+	"github.com/your-username/go-clean-architecture/pkg/logger"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+	"gorm.io/gorm/utils"
+)
+
+// GormLogger routes GORM logs through the application logger. Failed queries
+// are logged at error level, queries slower than the threshold at warn level,
+// and every other query at debug level when the log level is info.
+type GormLogger struct {
+	level         gormlogger.LogLevel
+	slowThreshold time.Duration
+	logParams     bool
+}
+
+// NewGormLogger creates a GORM logger from the database configuration
+func NewGormLogger(cfg *config.DatabaseConfig) *GormLogger {
+	return &GormLogger{
+		level:         parseGormLogLevel(cfg.LogLevel),
+		slowThreshold: cfg.SlowQueryThreshold,
+		logParams:     cfg.LogParams,
+	}
+}
+
+// LogMode implements gormlogger.Interface
+func (l *GormLogger) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
+	clone := *l
+	clone.level = level
+	return &clone
+}
+
+// Info implements gormlogger.Interface
+func (l *GormLogger) Info(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= gormlogger.Info {
+		logger.WithContext(ctx).Infof(msg, data...)
+	}
+}
+
+// Warn implements gormlogger.Interface
+func (l *GormLogger) Warn(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= gormlogger.Warn {
+		logger.WithContext(ctx).Warnf(msg, data...)
+	}
+}
+
+// Error implements gormlogger.Interface
+func (l *GormLogger) Error(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= gormlogger.Error {
+		logger.WithContext(ctx).Errorf(msg, data...)
+	}
+}
+
+// Trace implements gormlogger.Interface
+func (l *GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	if l.level <= gormlogger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	entry := func() *logrus.Entry {
+		sql, rows := fc()
+		return logger.WithContext(ctx).WithFields(logrus.Fields{
+			"sql":         sql,
+			"duration_ms": float64(elapsed.Microseconds()) / 1000,
+			"rows":        rows,
+			"source":      utils.FileWithLineNum(),
+		})
+	}
+
+	switch {
+	case err != nil && l.level >= gormlogger.Error && !errors.Is(err, gorm.ErrRecordNotFound):
+		entry().WithField("error", err).Error("Database query failed")
+	case l.slowThreshold > 0 && elapsed > l.slowThreshold && l.level >= gormlogger.Warn:
+		entry().Warnf("Slow query over %s", l.slowThreshold)
+	case l.level >= gormlogger.Info && logger.Log.IsLevelEnabled(logrus.DebugLevel):
+		entry().Debug("Database query")
+	}
+}
+
+// ParamsFilter implements gorm.ParamsFilter. Unless parameter logging is
+// enabled, logged SQL keeps its placeholders so values such as password
+// hashes never reach the logs.
+func (l *GormLogger) ParamsFilter(ctx context.Context, sql string, params ...interface{}) (string, []interface{}) {
+	if l.logParams {
+		return sql, params
+	}
+	return sql, nil
+}
+
+// parseGormLogLevel maps a level name to a GORM log level, defaulting to warn
+func parseGormLogLevel(level string) gormlogger.LogLevel {
+	switch strings.ToLower(level) {
+	case "silent":
+		return gormlogger.Silent
+	case "error":
+		return gormlogger.Error
+	case "info":
+		return gormlogger.Info
+	default:
+		return gormlogger.Warn
+	}
+}
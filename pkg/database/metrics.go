@@ -0,0 +1,55 @@
+package database
+
+import (
+	"errors"
+	"time"
+
+	"github.com/your-username/go-clean-architecture/pkg/metrics"
+	"gorm.io/gorm"
+)
+
+// metricsStartKey is the statement setting holding the start of a running query
+const metricsStartKey = "app:metrics_start"
+
+// RegisterMetrics records the count, duration and errors of every query in
+// Prometheus, labeled by operation and, when withTable is set, by table.
+// Labels never contain SQL, so their cardinality is bounded by the schema.
+func RegisterMetrics(db *gorm.DB, withTable bool) error {
+	start := func(tx *gorm.DB) {
+		tx.InstanceSet(metricsStartKey, time.Now())
+	}
+	end := func(operation string) func(tx *gorm.DB) {
+		return func(tx *gorm.DB) {
+			value, ok := tx.InstanceGet(metricsStartKey)
+			if !ok {
+				return
+			}
+
+			var table string
+			if withTable {
+				table = tx.Statement.Table
+			}
+			metrics.DBQueriesTotal.WithLabelValues(operation, table).Inc()
+			metrics.DBQueryDuration.WithLabelValues(operation, table).Observe(time.Since(value.(time.Time)).Seconds())
+			if tx.Error != nil && !errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+				metrics.DBQueryErrorsTotal.WithLabelValues(operation, table).Inc()
+			}
+		}
+	}
+
+	cb := db.Callback()
+	return errors.Join(
+		cb.Create().Before("gorm:create").Register("app:metrics_start", start),
+		cb.Create().After("gorm:after_create").Register("app:metrics_end", end("create")),
+		cb.Query().Before("gorm:query").Register("app:metrics_start", start),
+		cb.Query().After("gorm:after_query").Register("app:metrics_end", end("query")),
+		cb.Update().Before("gorm:update").Register("app:metrics_start", start),
+		cb.Update().After("gorm:after_update").Register("app:metrics_end", end("update")),
+		cb.Delete().Before("gorm:delete").Register("app:metrics_start", start),
+		cb.Delete().After("gorm:after_delete").Register("app:metrics_end", end("delete")),
+		cb.Row().Before("gorm:row").Register("app:metrics_start", start),
+		cb.Row().After("gorm:row").Register("app:metrics_end", end("row")),
+		cb.Raw().Before("gorm:raw").Register("app:metrics_start", start),
+		cb.Raw().After("gorm:raw").Register("app:metrics_end", end("raw")),
+	)
+}
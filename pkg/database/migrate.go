@@ -0,0 +1,61 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/your-username/go-clean-architecture/config"
+	"github.com/your-username/go-clean-architecture/database/migrations"
+	"github.com/your-username/go-clean-architecture/pkg/logger"
+)
+
+// RunMigrations applies the embedded SQL migrations that have not run yet,
+// the same ones cmd/migrate applies. The migrations are written for
+// Postgres, so SQLite databases are rejected.
+func (d *Database) RunMigrations(ctx context.Context) error {
+	if d.DB.Dialector.Name() != config.DBDriverPostgres {
+		return fmt.Errorf("SQL migrations require postgres, got %s", d.DB.Dialector.Name())
+	}
+
+	source, err := iofs.New(migrations.FS, ".")
+	if err != nil {
+		return fmt.Errorf("failed to read embedded migrations: %w", err)
+	}
+
+	sqlDB, err := d.DB.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get sql.DB: %w", err)
+	}
+	// A dedicated connection keeps the pool open when migrate closes its driver
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get database connection: %w", err)
+	}
+	driver, err := postgres.WithConnection(ctx, conn, &postgres.Config{})
+	if err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("failed to create migration driver: %w", err)
+	}
+
+	m, err := migrate.NewWithInstance("iofs", source, config.DBDriverPostgres, driver)
+	if err != nil {
+		_ = driver.Close()
+		return fmt.Errorf("failed to create migration instance: %w", err)
+	}
+	defer m.Close()
+
+	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("failed to run migrations: %w", err)
+	}
+
+	version, dirty, err := m.Version()
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return fmt.Errorf("failed to read migration version: %w", err)
+	}
+	logger.Infof("Database migrations applied, version %d (dirty: %v)", version, dirty)
+	return nil
+}
@@ -0,0 +1,113 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/your-username/go-clean-architecture/config"
+	"github.com/your-username/go-clean-architecture/pkg/pagination"
+	"gorm.io/gorm"
+)
+
+// Paginate returns a scope selecting one page of limit records. Pages start
+// at 1, page and limit below 1 are raised to 1, and limit is capped at the
+// configured maximum, see pagination.SetLimits.
+func Paginate(page, limit int) func(*gorm.DB) *gorm.DB {
+	offset, limit := pageBounds(page, limit)
+
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Offset(offset).Limit(limit)
+	}
+}
+
+// pageBounds returns the offset and limit of a page, normalized as Paginate
+// describes
+func pageBounds(page, limit int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 1
+	}
+	if maxLimit := pagination.Limits().MaxLimit; limit > maxLimit {
+		limit = maxLimit
+	}
+	return (page - 1) * limit, limit
+}
+
+// CountAndPaginate counts all records matching query and loads one page of
+// them into dest. The page query is skipped when it would be empty. query is
+// not modified, so it can carry filters and ordering for both queries.
+func CountAndPaginate(query *gorm.DB, page, limit int, dest interface{}) (int64, error) {
+	return PaginateWithTotal(query, page, limit, pagination.TotalExact, dest)
+}
+
+// PaginateWithTotal is CountAndPaginate computing the total as mode asks:
+// exactly, estimated from the Postgres planner's statistics, or not at all,
+// in which case the total is -1. Estimates fall back to an exact count on
+// other databases.
+func PaginateWithTotal(query *gorm.DB, page, limit int, mode pagination.TotalMode, dest interface{}) (int64, error) {
+	query = query.Session(&gorm.Session{})
+	if query.Statement.Model == nil {
+		query = query.Model(dest)
+	}
+	offset, _ := pageBounds(page, limit)
+
+	var total int64 = -1
+	switch mode {
+	case pagination.TotalOmitted:
+	case pagination.TotalEstimated:
+		estimate, err := estimateCount(query, dest)
+		if err != nil {
+			return 0, err
+		}
+		total = estimate
+	default:
+		if err := query.Count(&total).Error; err != nil {
+			return 0, err
+		}
+		// An exact total tells when the page would be empty
+		if total == 0 || int64(offset) >= total {
+			return total, nil
+		}
+	}
+
+	if err := query.Scopes(Paginate(page, limit)).Find(dest).Error; err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+// estimateCount returns the number of rows the Postgres planner expects query
+// to return, from the table statistics kept by ANALYZE (pg_class.reltuples
+// and column statistics), without scanning the table. Other databases count
+// exactly.
+func estimateCount(query *gorm.DB, dest interface{}) (int64, error) {
+	if query.Dialector.Name() != config.DBDriverPostgres {
+		var total int64
+		err := query.Count(&total).Error
+		return total, err
+	}
+
+	stmt := query.Session(&gorm.Session{DryRun: true}).Find(dest).Statement
+	var plan string
+	err := query.Session(&gorm.Session{NewDB: true}).
+		Raw("EXPLAIN (FORMAT JSON) "+stmt.SQL.String(), stmt.Vars...).
+		Row().Scan(&plan)
+	if err != nil {
+		return 0, err
+	}
+
+	var explained []struct {
+		Plan struct {
+			Rows float64 `json:"Plan Rows"`
+		} `json:"Plan"`
+	}
+	if err := json.Unmarshal([]byte(plan), &explained); err != nil {
+		return 0, fmt.Errorf("failed to read query plan: %w", err)
+	}
+	if len(explained) == 0 {
+		return 0, fmt.Errorf("failed to read query plan: empty plan")
+	}
+	return int64(explained[0].Plan.Rows), nil
+}
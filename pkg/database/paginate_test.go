@@ -0,0 +1,98 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/your-username/go-clean-architecture/config"
+	"github.com/your-username/go-clean-architecture/pkg/database/dbtest"
+	"github.com/your-username/go-clean-architecture/pkg/pagination"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// pageRow is the model of the Paginate tests
+type pageRow struct {
+	ID uint
+}
+
+func TestPaginate(t *testing.T) {
+	tests := []struct {
+		name       string
+		page       int
+		limit      int
+		wantOffset int
+		wantLimit  int
+	}{
+		{name: "first page", page: 1, limit: 10, wantOffset: 0, wantLimit: 10},
+		{name: "second page", page: 2, limit: 10, wantOffset: 10, wantLimit: 10},
+		{name: "later page", page: 7, limit: 25, wantOffset: 150, wantLimit: 25},
+		{name: "page 0 is the first", page: 0, limit: 10, wantOffset: 0, wantLimit: 10},
+		{name: "negative page is the first", page: -3, limit: 10, wantOffset: 0, wantLimit: 10},
+		{name: "limit 0 is raised to 1", page: 3, limit: 0, wantOffset: 2, wantLimit: 1},
+		{name: "negative limit is raised to 1", page: 3, limit: -5, wantOffset: 2, wantLimit: 1},
+		{name: "limit above the max is capped", page: 2, limit: 500, wantOffset: 50, wantLimit: 50},
+		{name: "limit at the max", page: 2, limit: 50, wantOffset: 50, wantLimit: 50},
+	}
+
+	pagination.SetLimits(config.PaginationConfig{MaxLimit: 50})
+	t.Cleanup(func() { pagination.SetLimits(config.PaginationConfig{}) })
+	db := dbtest.New(t, &pageRow{})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var rows []pageRow
+			stmt := db.Session(&gorm.Session{DryRun: true}).Scopes(Paginate(tt.page, tt.limit)).Find(&rows).Statement
+
+			limit, ok := stmt.Clauses["LIMIT"].Expression.(clause.Limit)
+			if !ok || limit.Limit == nil {
+				t.Fatalf("Paginate(%d, %d) set no limit", tt.page, tt.limit)
+			}
+			if limit.Offset != tt.wantOffset || *limit.Limit != tt.wantLimit {
+				t.Errorf("Paginate(%d, %d) = offset %d limit %d, want offset %d limit %d",
+					tt.page, tt.limit, limit.Offset, *limit.Limit, tt.wantOffset, tt.wantLimit)
+			}
+		})
+	}
+}
+
+func TestCountAndPaginate(t *testing.T) {
+	db := dbtest.New(t, &pageRow{})
+	for i := 0; i < 7; i++ {
+		if err := db.Create(&pageRow{}).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		page    int
+		limit   int
+		wantIDs []uint
+	}{
+		{name: "first page", page: 1, limit: 3, wantIDs: []uint{1, 2, 3}},
+		{name: "last partial page", page: 3, limit: 3, wantIDs: []uint{7}},
+		{name: "past the end", page: 4, limit: 3},
+		{name: "page 0", page: 0, limit: 3, wantIDs: []uint{1, 2, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var rows []pageRow
+			total, err := CountAndPaginate(db.Order("id"), tt.page, tt.limit, &rows)
+			if err != nil {
+				t.Fatalf("CountAndPaginate() error = %v", err)
+			}
+			if total != 7 {
+				t.Errorf("CountAndPaginate() total = %d, want 7", total)
+			}
+			if len(rows) != len(tt.wantIDs) {
+				t.Fatalf("CountAndPaginate() returned %d rows, want %v", len(rows), tt.wantIDs)
+			}
+			for i, row := range rows {
+				if row.ID != tt.wantIDs[i] {
+					t.Errorf("CountAndPaginate()[%d] = %d, want %d", i, row.ID, tt.wantIDs[i])
+				}
+			}
+		})
+	}
+}
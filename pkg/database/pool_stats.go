@@ -0,0 +1,67 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/your-username/go-clean-architecture/pkg/logger"
+	"github.com/your-username/go-clean-architecture/pkg/metrics"
+)
+
+// PoolStats returns the statistics of the primary connection pool
+func (d *Database) PoolStats() (sql.DBStats, error) {
+	sqlDB, err := d.DB.DB()
+	if err != nil {
+		return sql.DBStats{}, err
+	}
+	return sqlDB.Stats(), nil
+}
+
+// StartPoolStatsSampler copies the primary pool statistics to the Prometheus
+// gauges every interval, until the returned stop function is called
+func (d *Database) StartPoolStatsSampler(interval time.Duration) (stop func(ctx context.Context) error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			d.samplePoolStats()
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return func(ctx context.Context) error {
+		cancel()
+		select {
+		case <-done:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// samplePoolStats sets the pool gauges from the current statistics
+func (d *Database) samplePoolStats() {
+	stats, err := d.PoolStats()
+	if err != nil {
+		logger.Warnf("Failed to read database pool stats: %v", err)
+		return
+	}
+
+	metrics.DBConnectionsOpen.Set(float64(stats.OpenConnections))
+	metrics.DBConnectionsInUse.Set(float64(stats.InUse))
+	metrics.DBConnectionsIdle.Set(float64(stats.Idle))
+	metrics.DBConnectionsMaxOpen.Set(float64(stats.MaxOpenConnections))
+	metrics.DBConnectionsWaitCount.Set(float64(stats.WaitCount))
+	metrics.DBConnectionsWaitDuration.Set(stats.WaitDuration.Seconds())
+}
@@ -1,32 +1,31 @@
 package database
 
 import (
+	"database/sql"
 	"fmt"
+	"strings"
 
 	"github.com/your-username/go-clean-architecture/config"
 	"github.com/your-username/go-clean-architecture/pkg/logger"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
-	gormlogger "gorm.io/gorm/logger"
 )
 
 // Database holds the database connection
 type Database struct {
-	DB *gorm.DB
+	DB    *gorm.DB
+	retry config.RetryConfig
 }
 
 // NewDatabase creates a new database connection
 func NewDatabase(cfg *config.DatabaseConfig) (*Database, error) {
-	dsn := cfg.GetDSN()
-
-	// Configure GORM logger
-	logLevel := gormlogger.Silent
-	if cfg.SSLMode == "disable" {
-		logLevel = gormlogger.Info
+	dialector, err := openDialector(cfg)
+	if err != nil {
+		return nil, err
 	}
 
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
-		Logger: gormlogger.Default.LogMode(logLevel),
+	db, err := gorm.Open(dialector, &gorm.Config{
+		Logger: NewGormLogger(cfg),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
@@ -38,13 +37,43 @@ func NewDatabase(cfg *config.DatabaseConfig) (*Database, error) {
 		return nil, fmt.Errorf("failed to get sql.DB: %w", err)
 	}
 
+	// Route reads to replicas, if any. This must happen before the primary
+	// pool is configured since the resolver's pool settings apply to it too.
+	if err := registerReplicas(db, cfg); err != nil {
+		return nil, err
+	}
+
 	// Set connection pool settings
-	sqlDB.SetMaxIdleConns(10)
-	sqlDB.SetMaxOpenConns(100)
+	pool := normalizePool(cfg.Pool, "DB_")
+	if cfg.Driver == config.DBDriverSQLite && isSQLiteMemory(cfg.DBName) {
+		// Every connection to :memory: opens a separate, empty database
+		pool.MaxOpenConns = 1
+		pool.MaxIdleConns = 1
+		pool.ConnMaxLifetime = 0
+		pool.ConnMaxIdleTime = 0
+	}
+	applyPool(sqlDB, pool)
 
 	logger.Info("Database connected successfully")
 
-	return &Database{DB: db}, nil
+	return &Database{DB: db, retry: cfg.Retry}, nil
+}
+
+// openDialector returns the GORM dialector for the configured driver
+func openDialector(cfg *config.DatabaseConfig) (gorm.Dialector, error) {
+	switch cfg.Driver {
+	case "", config.DBDriverPostgres:
+		return postgres.Open(cfg.GetDSN()), nil
+	case config.DBDriverSQLite:
+		return openSQLite(cfg.GetDSN())
+	default:
+		return nil, fmt.Errorf("unknown database driver: %s", cfg.Driver)
+	}
+}
+
+// isSQLiteMemory reports whether a SQLite DSN names an in-memory database
+func isSQLiteMemory(dsn string) bool {
+	return dsn == ":memory:" || strings.Contains(dsn, "mode=memory") || strings.HasPrefix(dsn, "file::memory:")
 }
 
 // Close closes the database connection
@@ -60,3 +89,45 @@ func (d *Database) Close() error {
 func (d *Database) AutoMigrate(models ...interface{}) error {
 	return d.DB.AutoMigrate(models...)
 }
+
+// Connection pool defaults, used when configured values are invalid
+const (
+	defaultMaxIdleConns = 10
+	defaultMaxOpenConns = 100
+)
+
+// normalizePool clamps invalid pool settings with a warning. envPrefix names
+// the environment variables in the warnings, e.g. "DB_" or "DB_REPLICA_".
+func normalizePool(pool config.PoolConfig, envPrefix string) config.PoolConfig {
+	if pool.MaxOpenConns < 0 {
+		logger.Warnf("%sMAX_OPEN_CONNS=%d is negative, using %d", envPrefix, pool.MaxOpenConns, defaultMaxOpenConns)
+		pool.MaxOpenConns = defaultMaxOpenConns
+	}
+	if pool.MaxIdleConns < 0 {
+		logger.Warnf("%sMAX_IDLE_CONNS=%d is negative, using %d", envPrefix, pool.MaxIdleConns, defaultMaxIdleConns)
+		pool.MaxIdleConns = defaultMaxIdleConns
+	}
+	// Zero open connections means unlimited
+	if pool.MaxOpenConns > 0 && pool.MaxIdleConns > pool.MaxOpenConns {
+		logger.Warnf("%sMAX_IDLE_CONNS=%d exceeds %sMAX_OPEN_CONNS=%d, clamping",
+			envPrefix, pool.MaxIdleConns, envPrefix, pool.MaxOpenConns)
+		pool.MaxIdleConns = pool.MaxOpenConns
+	}
+	if pool.ConnMaxLifetime < 0 {
+		logger.Warnf("%sCONN_MAX_LIFETIME_SECONDS is negative, connections will not expire", envPrefix)
+		pool.ConnMaxLifetime = 0
+	}
+	if pool.ConnMaxIdleTime < 0 {
+		logger.Warnf("%sCONN_MAX_IDLE_TIME_SECONDS is negative, idle connections will not expire", envPrefix)
+		pool.ConnMaxIdleTime = 0
+	}
+	return pool
+}
+
+// applyPool applies pool settings to a connection pool
+func applyPool(sqlDB *sql.DB, pool config.PoolConfig) {
+	sqlDB.SetMaxOpenConns(pool.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(pool.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(pool.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(pool.ConnMaxIdleTime)
+}
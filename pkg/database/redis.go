@@ -2,7 +2,11 @@ package database
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
+	"net"
+	"net/url"
 	"time"
 
 	"github.com/go-redis/redis/v8"
@@ -10,20 +14,24 @@ import (
 	"github.com/your-username/go-clean-architecture/pkg/logger"
 )
 
-// RedisClient holds the redis client
+// ErrRedisTimeout is returned when a Redis operation exceeds its deadline
+var ErrRedisTimeout = errors.New("redis operation timed out")
+
+// RedisClient holds the redis client. Its methods bound each operation by
+// the configured timeout unless the caller's context already has a deadline;
+// Client is exposed for commands without a wrapper, which are not bounded.
 type RedisClient struct {
-	Client *redis.Client
+	Client  *redis.Client
+	timeout time.Duration
 }
 
 // NewRedisClient creates a new redis client
 func NewRedisClient(cfg *config.RedisConfig) (*RedisClient, error) {
-	addr := fmt.Sprintf("%s:%s", cfg.Host, cfg.Port)
-
-	client := redis.NewClient(&redis.Options{
-		Addr:     addr,
-		Password: cfg.Password,
-		DB:       cfg.DB,
-	})
+	opts, err := redisOptions(cfg)
+	if err != nil {
+		return nil, err
+	}
+	client := redis.NewClient(opts)
 
 	// Test connection
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -35,7 +43,54 @@ func NewRedisClient(cfg *config.RedisConfig) (*RedisClient, error) {
 
 	logger.Info("Redis connected successfully")
 
-	return &RedisClient{Client: client}, nil
+	return &RedisClient{Client: client, timeout: cfg.Timeout}, nil
+}
+
+// redisOptions builds the client options. REDIS_URL, when set, replaces the
+// host, port, password and database settings; a rediss:// URL enables TLS
+// like REDIS_TLS does.
+func redisOptions(cfg *config.RedisConfig) (*redis.Options, error) {
+	opts := &redis.Options{
+		Addr:     fmt.Sprintf("%s:%s", cfg.Host, cfg.Port),
+		Password: cfg.Password,
+		DB:       cfg.DB,
+	}
+	if cfg.URL != "" {
+		parsed, err := redis.ParseURL(cfg.URL)
+		if err != nil {
+			// Leave out the URL, it may hold the password
+			var urlErr *url.Error
+			if errors.As(err, &urlErr) {
+				err = urlErr.Err
+			}
+			return nil, fmt.Errorf("invalid REDIS_URL: %w", err)
+		}
+		opts = parsed
+	}
+
+	opts.DialTimeout = cfg.DialTimeout
+	opts.ReadTimeout = cfg.ReadTimeout
+	opts.WriteTimeout = cfg.WriteTimeout
+	opts.PoolSize = cfg.PoolSize
+	opts.MinIdleConns = cfg.MinIdleConns
+	// The client reads 0 as its default of 3 retries and -1 as none
+	opts.MaxRetries = cfg.MaxRetries
+	if opts.MaxRetries == 0 {
+		opts.MaxRetries = -1
+	}
+
+	if cfg.TLS && opts.TLSConfig == nil {
+		host, _, err := net.SplitHostPort(opts.Addr)
+		if err != nil {
+			host = opts.Addr
+		}
+		opts.TLSConfig = &tls.Config{ServerName: host}
+	}
+	if opts.TLSConfig != nil {
+		opts.TLSConfig.MinVersion = tls.VersionTLS12
+	}
+
+	return opts, nil
 }
 
 // Close closes the redis connection
@@ -43,26 +98,84 @@ func (r *RedisClient) Close() error {
 	return r.Client.Close()
 }
 
+// withTimeout bounds ctx by the operation timeout unless it already has a
+// deadline or the timeout is not positive
+func (r *RedisClient) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if r.timeout <= 0 {
+		return ctx, func() {}
+	}
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, r.timeout)
+}
+
+// redisError wraps timeouts, whether from ctx or a socket deadline, in
+// ErrRedisTimeout. Other errors, including redis.Nil, are returned as is.
+func redisError(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(ctx.Err(), context.DeadlineExceeded) ||
+		(errors.As(err, &netErr) && netErr.Timeout()) {
+		return fmt.Errorf("%w: %v", ErrRedisTimeout, err)
+	}
+	return err
+}
+
 // Set sets a key-value pair with expiration
 func (r *RedisClient) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
-	return r.Client.Set(ctx, key, value, expiration).Err()
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	return redisError(ctx, r.Client.Set(ctx, key, value, expiration).Err())
+}
+
+// SetNX sets a key-value pair with expiration unless the key exists, and
+// reports whether it was set
+func (r *RedisClient) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	set, err := r.Client.SetNX(ctx, key, value, expiration).Result()
+	return set, redisError(ctx, err)
 }
 
-// Get gets a value by key
+// Get gets a value by key. A missing key is reported as redis.Nil.
 func (r *RedisClient) Get(ctx context.Context, key string) (string, error) {
-	return r.Client.Get(ctx, key).Result()
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	value, err := r.Client.Get(ctx, key).Result()
+	return value, redisError(ctx, err)
 }
 
 // Delete deletes a key
 func (r *RedisClient) Delete(ctx context.Context, keys ...string) error {
-	return r.Client.Del(ctx, keys...).Err()
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	return redisError(ctx, r.Client.Del(ctx, keys...).Err())
 }
 
 // Exists checks if a key exists
 func (r *RedisClient) Exists(ctx context.Context, key string) (bool, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
 	result, err := r.Client.Exists(ctx, key).Result()
 	if err != nil {
-		return false, err
+		return false, redisError(ctx, err)
 	}
 	return result > 0, nil
 }
+
+// Publish sends a message to a channel
+func (r *RedisClient) Publish(ctx context.Context, channel string, message interface{}) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	return redisError(ctx, r.Client.Publish(ctx, channel, message).Err())
+}
@@ -0,0 +1,138 @@
+package database
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/your-username/go-clean-architecture/pkg/logger"
+)
+
+const (
+	// lockKeyPrefix namespaces lock keys
+	lockKeyPrefix = "lock:"
+	// defaultLockRetryInterval is the wait between attempts of a blocking acquire
+	defaultLockRetryInterval = 100 * time.Millisecond
+	// lockReleaseTimeout bounds the release call, which may run after the
+	// caller's context is done
+	lockReleaseTimeout = 2 * time.Second
+)
+
+// releaseLockScript deletes the lock only while it still holds our token, so
+// a holder whose lock expired cannot release a lock taken by someone else
+var releaseLockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// acquireLeaseScript takes the lease when it is free or renews it when
+// ARGV[1] already holds it, and returns the holder of the lease afterwards
+var acquireLeaseScript = redis.NewScript(`
+local holder = redis.call("GET", KEYS[1])
+if holder == ARGV[1] then
+	redis.call("PEXPIRE", KEYS[1], ARGV[2])
+	return holder
+end
+if not holder then
+	redis.call("SET", KEYS[1], ARGV[1], "PX", ARGV[2])
+	return ARGV[1]
+end
+return holder
+`)
+
+// AcquireLock tries once to take the lock named key for ttl. When acquired,
+// unlock releases it; the lock also expires after ttl if never released, so
+// ttl must exceed the time the protected work takes.
+func (r *RedisClient) AcquireLock(ctx context.Context, key string, ttl time.Duration) (unlock func(), acquired bool, err error) {
+	token, err := lockToken()
+	if err != nil {
+		return nil, false, err
+	}
+
+	acquired, err = r.SetNX(ctx, lockKeyPrefix+key, token, ttl)
+	if err != nil || !acquired {
+		return nil, false, err
+	}
+
+	unlock = func() { r.releaseLock(key, token) }
+	return unlock, true, nil
+}
+
+// releaseLock deletes the lock while it holds token. It runs on its own
+// timeout, as the caller's context may already be done.
+func (r *RedisClient) releaseLock(key, token string) {
+	ctx, cancel := context.WithTimeout(context.Background(), lockReleaseTimeout)
+	defer cancel()
+	if err := releaseLockScript.Run(ctx, r.Client, []string{lockKeyPrefix + key}, token).Err(); err != nil {
+		logger.Warnf("Failed to release lock %s: %v", key, err)
+	}
+}
+
+// AcquireLease takes the lease named key for holder, or renews it when holder
+// already has it, for ttl. It returns the holder of the lease afterwards, so
+// holder has it when the two are equal. Unlike a lock, a lease is stored
+// under its holder's name, which must be unique among the contenders, so
+// that they can tell who has it.
+func (r *RedisClient) AcquireLease(ctx context.Context, key, holder string, ttl time.Duration) (string, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	current, err := acquireLeaseScript.Run(ctx, r.Client, []string{lockKeyPrefix + key}, holder, ttl.Milliseconds()).Text()
+	if err != nil {
+		return "", redisError(ctx, err)
+	}
+	return current, nil
+}
+
+// ReleaseLease gives up the lease named key while holder has it
+func (r *RedisClient) ReleaseLease(key, holder string) {
+	r.releaseLock(key, holder)
+}
+
+// AcquireLockWait retries AcquireLock every retry interval until the lock is
+// taken, timeout passes or ctx is done. It reports acquired false, without
+// an error, when the timeout passes first.
+func (r *RedisClient) AcquireLockWait(ctx context.Context, key string, ttl, timeout, retry time.Duration) (unlock func(), acquired bool, err error) {
+	if retry <= 0 {
+		retry = defaultLockRetryInterval
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(retry)
+	defer ticker.Stop()
+
+	deadline, _ := waitCtx.Deadline()
+	for {
+		unlock, acquired, err = r.AcquireLock(waitCtx, key, ttl)
+		// The socket deadline, taken from waitCtx, can expire just before
+		// waitCtx reports it, so check the clock too
+		if acquired || (err != nil && waitCtx.Err() == nil && time.Now().Before(deadline)) {
+			return unlock, acquired, err
+		}
+
+		select {
+		case <-waitCtx.Done():
+			// Only the caller's own cancellation is an error
+			if err := ctx.Err(); err != nil {
+				return nil, false, err
+			}
+			return nil, false, nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// lockToken returns a random token identifying one lock holder
+func lockToken() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,157 @@
+package database
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/your-username/go-clean-architecture/pkg/logger"
+)
+
+func TestMain(m *testing.M) {
+	logger.InitLogger(false)
+	os.Exit(m.Run())
+}
+
+// newTestRedis returns a client of a fresh miniredis server
+func newTestRedis(t *testing.T) (*RedisClient, *miniredis.Miniredis) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr(), MaxRetries: -1})
+	t.Cleanup(func() { _ = client.Close() })
+	return &RedisClient{Client: client, timeout: time.Second}, server
+}
+
+func TestAcquireLockContention(t *testing.T) {
+	client, _ := newTestRedis(t)
+
+	// Repeat the race a few times, the winner varies between rounds
+	for round := 0; round < 20; round++ {
+		var (
+			start   = make(chan struct{})
+			wg      sync.WaitGroup
+			mu      sync.Mutex
+			winners int
+			unlocks []func()
+		)
+		for i := 0; i < 2; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				<-start
+				unlock, acquired, err := client.AcquireLock(context.Background(), "job", time.Minute)
+				if err != nil {
+					t.Errorf("AcquireLock() error = %v", err)
+					return
+				}
+				if acquired {
+					mu.Lock()
+					winners++
+					unlocks = append(unlocks, unlock)
+					mu.Unlock()
+				}
+			}()
+		}
+		close(start)
+		wg.Wait()
+
+		if winners != 1 {
+			t.Fatalf("round %d: %d goroutines acquired the lock, want exactly 1", round, winners)
+		}
+		unlocks[0]()
+	}
+}
+
+func TestLockReleaseOnlyByOwner(t *testing.T) {
+	client, server := newTestRedis(t)
+	ctx := context.Background()
+	ttl := time.Minute
+
+	unlock, acquired, err := client.AcquireLock(ctx, "job", ttl)
+	if err != nil || !acquired {
+		t.Fatalf("AcquireLock() = %v, %v; want acquired", acquired, err)
+	}
+
+	// Another holder cannot release it
+	client.releaseLock("job", "other")
+	if _, acquired, _ := client.AcquireLock(ctx, "job", ttl); acquired {
+		t.Fatal("releaseLock() by another holder freed the lock")
+	}
+
+	// Once expired and taken over, the old owner cannot release the new lock
+	server.FastForward(ttl + time.Second)
+	_, acquired, err = client.AcquireLock(ctx, "job", ttl)
+	if err != nil || !acquired {
+		t.Fatalf("AcquireLock() after expiry = %v, %v; want acquired", acquired, err)
+	}
+	unlock()
+	if _, acquired, _ := client.AcquireLock(ctx, "job", ttl); acquired {
+		t.Error("unlock() by the expired owner freed the new lock")
+	}
+}
+
+func TestAcquireLockWait(t *testing.T) {
+	client, _ := newTestRedis(t)
+	ctx := context.Background()
+
+	unlock, acquired, err := client.AcquireLock(ctx, "job", time.Minute)
+	if err != nil || !acquired {
+		t.Fatalf("AcquireLock() = %v, %v; want acquired", acquired, err)
+	}
+
+	if _, acquired, err := client.AcquireLockWait(ctx, "job", time.Minute, 100*time.Millisecond, 10*time.Millisecond); err != nil || acquired {
+		t.Fatalf("AcquireLockWait() while held = %v, %v; want not acquired without error", acquired, err)
+	}
+
+	time.AfterFunc(50*time.Millisecond, unlock)
+	unlockWait, acquired, err := client.AcquireLockWait(ctx, "job", time.Minute, time.Second, 10*time.Millisecond)
+	if err != nil || !acquired {
+		t.Fatalf("AcquireLockWait() after release = %v, %v; want acquired", acquired, err)
+	}
+	unlockWait()
+}
+
+func TestAcquireLease(t *testing.T) {
+	client, server := newTestRedis(t)
+	ctx := context.Background()
+	ttl := time.Minute
+
+	holder, err := client.AcquireLease(ctx, "leader", "a", ttl)
+	if err != nil || holder != "a" {
+		t.Fatalf("AcquireLease(a) = %q, %v; want a", holder, err)
+	}
+	holder, err = client.AcquireLease(ctx, "leader", "b", ttl)
+	if err != nil || holder != "a" {
+		t.Fatalf("AcquireLease(b) while a holds it = %q, %v; want a", holder, err)
+	}
+
+	// Renewing resets the expiry
+	server.FastForward(ttl / 2)
+	if holder, err = client.AcquireLease(ctx, "leader", "a", ttl); err != nil || holder != "a" {
+		t.Fatalf("renewing AcquireLease(a) = %q, %v; want a", holder, err)
+	}
+	server.FastForward(ttl * 3 / 4)
+	if holder, _ = client.AcquireLease(ctx, "leader", "b", ttl); holder != "a" {
+		t.Fatalf("lease expired despite renewal, holder = %q", holder)
+	}
+
+	// Only the holder releases the lease
+	client.ReleaseLease("leader", "b")
+	if holder, _ = client.AcquireLease(ctx, "leader", "b", ttl); holder != "a" {
+		t.Fatalf("ReleaseLease by another replica freed the lease, holder = %q", holder)
+	}
+	client.ReleaseLease("leader", "a")
+	if holder, _ = client.AcquireLease(ctx, "leader", "b", ttl); holder != "b" {
+		t.Fatalf("AcquireLease(b) after release = %q, want b", holder)
+	}
+
+	// An expired lease is taken over
+	server.FastForward(ttl + time.Second)
+	if holder, _ = client.AcquireLease(ctx, "leader", "a", ttl); holder != "a" {
+		t.Fatalf("AcquireLease(a) after expiry = %q, want a", holder)
+	}
+}
@@ -0,0 +1,138 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/your-username/go-clean-architecture/config"
+)
+
+// newUnresponsiveRedis returns a client of a server that accepts connections
+// and never answers
+func newUnresponsiveRedis(t *testing.T, timeout time.Duration) *RedisClient {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				_, _ = io.Copy(io.Discard, conn)
+			}()
+		}
+	}()
+
+	// No socket read timeout, so only the operation timeout can end a call
+	client := redis.NewClient(&redis.Options{Addr: ln.Addr().String(), MaxRetries: -1, ReadTimeout: -1})
+	t.Cleanup(func() { _ = client.Close() })
+	return &RedisClient{Client: client, timeout: timeout}
+}
+
+func TestRedisClientOperations(t *testing.T) {
+	client, _ := newTestRedis(t)
+	ctx := context.Background()
+
+	if err := client.Set(ctx, "greeting", "hello", time.Minute); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if value, err := client.Get(ctx, "greeting"); err != nil || value != "hello" {
+		t.Fatalf("Get() = %q, %v; want hello", value, err)
+	}
+	if set, err := client.SetNX(ctx, "greeting", "bye", time.Minute); err != nil || set {
+		t.Fatalf("SetNX() on an existing key = %v, %v; want false", set, err)
+	}
+	if err := client.Delete(ctx, "greeting"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if exists, err := client.Exists(ctx, "greeting"); err != nil || exists {
+		t.Fatalf("Exists() after Delete() = %v, %v; want false", exists, err)
+	}
+	if _, err := client.Get(ctx, "greeting"); !errors.Is(err, redis.Nil) {
+		t.Fatalf("Get() of a missing key error = %v, want redis.Nil", err)
+	}
+}
+
+func TestRedisClientTimesOutUnresponsiveServer(t *testing.T) {
+	client := newUnresponsiveRedis(t, 100*time.Millisecond)
+
+	tests := []struct {
+		name string
+		call func(ctx context.Context) error
+	}{
+		{name: "Set", call: func(ctx context.Context) error { return client.Set(ctx, "key", "value", time.Minute) }},
+		{name: "SetNX", call: func(ctx context.Context) error {
+			_, err := client.SetNX(ctx, "key", "value", time.Minute)
+			return err
+		}},
+		{name: "Get", call: func(ctx context.Context) error {
+			_, err := client.Get(ctx, "key")
+			return err
+		}},
+		{name: "Delete", call: func(ctx context.Context) error { return client.Delete(ctx, "key") }},
+		{name: "Exists", call: func(ctx context.Context) error {
+			_, err := client.Exists(ctx, "key")
+			return err
+		}},
+		{name: "Publish", call: func(ctx context.Context) error { return client.Publish(ctx, "channel", "message") }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := time.Now()
+			err := tt.call(context.Background())
+			if !errors.Is(err, ErrRedisTimeout) {
+				t.Fatalf("%s() error = %v, want ErrRedisTimeout", tt.name, err)
+			}
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Errorf("%s() took %s, want it bounded by the timeout", tt.name, elapsed)
+			}
+		})
+	}
+}
+
+func TestRedisClientKeepsCallerDeadline(t *testing.T) {
+	// The operation timeout is far off, so only the caller's deadline ends the call
+	client := newUnresponsiveRedis(t, time.Minute)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if _, err := client.Get(ctx, "key"); !errors.Is(err, ErrRedisTimeout) {
+		t.Fatalf("Get() error = %v, want ErrRedisTimeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Get() took %s, want it bounded by the caller's deadline", elapsed)
+	}
+}
+
+func TestRedisOptionsApplyTimeouts(t *testing.T) {
+	cfg := &config.RedisConfig{
+		Host:         "localhost",
+		Port:         "6379",
+		DialTimeout:  5 * time.Second,
+		ReadTimeout:  2 * time.Second,
+		WriteTimeout: 4 * time.Second,
+	}
+	opts, err := redisOptions(cfg)
+	if err != nil {
+		t.Fatalf("redisOptions() error = %v", err)
+	}
+	if opts.DialTimeout != cfg.DialTimeout || opts.ReadTimeout != cfg.ReadTimeout || opts.WriteTimeout != cfg.WriteTimeout {
+		t.Errorf("redisOptions() timeouts = dial %s, read %s, write %s; want %s, %s, %s",
+			opts.DialTimeout, opts.ReadTimeout, opts.WriteTimeout, cfg.DialTimeout, cfg.ReadTimeout, cfg.WriteTimeout)
+	}
+}
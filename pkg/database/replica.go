@@ -0,0 +1,74 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/your-username/go-clean-architecture/config"
+	"github.com/your-username/go-clean-architecture/pkg/logger"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+// primaryKey is the context key that forces queries onto the primary
+type primaryKey struct{}
+
+// WithPrimary returns a copy of ctx whose queries always run on the primary.
+// Use it for reads that must observe a write made just before, since
+// replicas may lag behind.
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+// primaryForced reports whether ctx was marked with WithPrimary
+func primaryForced(ctx context.Context) bool {
+	forced, _ := ctx.Value(primaryKey{}).(bool)
+	return forced
+}
+
+// registerReplicas routes reads to the configured replicas and writes to the
+// primary. It is a no-op when no replicas are configured.
+func registerReplicas(db *gorm.DB, cfg *config.DatabaseConfig) error {
+	if len(cfg.Replicas) == 0 {
+		return nil
+	}
+
+	replicas := make([]gorm.Dialector, 0, len(cfg.Replicas))
+	for _, dsn := range cfg.Replicas {
+		replicas = append(replicas, postgres.Open(dsn))
+	}
+
+	resolver := dbresolver.Register(dbresolver.Config{
+		Replicas: replicas,
+		Policy:   dbresolver.RandomPolicy{},
+	})
+	if err := db.Use(resolver); err != nil {
+		return fmt.Errorf("failed to register read replicas: %w", err)
+	}
+	pool := normalizePool(cfg.ReplicaPool, "DB_REPLICA_")
+	resolver.SetMaxOpenConns(pool.MaxOpenConns).
+		SetMaxIdleConns(pool.MaxIdleConns).
+		SetConnMaxLifetime(pool.ConnMaxLifetime).
+		SetConnMaxIdleTime(pool.ConnMaxIdleTime)
+
+	// Honour WithPrimary for reads; writes always go to the primary. This runs
+	// after the resolver picked a replica and switches the statement back.
+	forcePrimary := func(tx *gorm.DB) {
+		if tx.Statement.Context != nil && primaryForced(tx.Statement.Context) {
+			dbresolver.Write.ModifyStatement(tx.Statement)
+		}
+	}
+	if err := db.Callback().Query().After("gorm:db_resolver").Before("gorm:query").Register("app:force_primary", forcePrimary); err != nil {
+		return err
+	}
+	if err := db.Callback().Row().After("gorm:db_resolver").Before("gorm:row").Register("app:force_primary", forcePrimary); err != nil {
+		return err
+	}
+	if err := db.Callback().Raw().After("gorm:db_resolver").Before("gorm:raw").Register("app:force_primary", forcePrimary); err != nil {
+		return err
+	}
+
+	logger.Infof("Read replicas registered: %d", len(replicas))
+	return nil
+}
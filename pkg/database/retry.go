@@ -0,0 +1,117 @@
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"github.com/your-username/go-clean-architecture/config"
+	"github.com/your-username/go-clean-architecture/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// defaultRetryBaseDelay is the first backoff of WithRetry
+const defaultRetryBaseDelay = 50 * time.Millisecond
+
+// retryableSQLStates are Postgres error codes after which the whole
+// transaction can safely run again
+var retryableSQLStates = map[string]bool{
+	"40001": true, // serialization_failure
+	"40P01": true, // deadlock_detected
+}
+
+// IsRetryable reports whether err is a transient database error: a
+// serialization failure, a deadlock, or a connection failure that happened
+// before the statement reached the server
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, driver.ErrBadConn) {
+		return true
+	}
+
+	// Checked through interfaces so both pgx and lib/pq errors are recognized
+	var coded interface{ SQLState() string }
+	if errors.As(err, &coded) && retryableSQLStates[coded.SQLState()] {
+		return true
+	}
+	var safe interface{ SafeToRetry() bool }
+	return errors.As(err, &safe) && safe.SafeToRetry()
+}
+
+// WithRetry calls fn until it succeeds, returns an error that is not
+// retryable, or maxAttempts calls were made. Attempts are separated by an
+// exponential backoff with jitter. fn must be safe to run again, e.g. a whole
+// transaction rather than a single statement of one.
+func WithRetry(ctx context.Context, maxAttempts int, fn func() error) error {
+	return withRetry(ctx, maxAttempts, defaultRetryBaseDelay, fn)
+}
+
+// withRetry implements WithRetry with a configurable first backoff
+func withRetry(ctx context.Context, maxAttempts int, baseDelay time.Duration, fn func() error) error {
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+	if baseDelay <= 0 {
+		baseDelay = defaultRetryBaseDelay
+	}
+
+	delay := baseDelay
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || attempt >= maxAttempts || !IsRetryable(err) {
+			return err
+		}
+
+		// Jitter spreads out retries of transactions that conflicted together
+		wait := delay/2 + rand.N(delay)
+		logger.Warnf("Transient database error, retrying in %s (attempt %d of %d): %v", wait, attempt+1, maxAttempts, err)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return errors.Join(err, ctx.Err())
+		}
+		delay *= 2
+	}
+}
+
+// Transactor runs functions in transactions. Repositories take one rather
+// than opening transactions on their *gorm.DB, so every transaction of the
+// app is retried on transient errors.
+type Transactor interface {
+	Transaction(ctx context.Context, fn func(tx *gorm.DB) error) error
+}
+
+// retryingTransactor runs transactions on db, running the whole transaction
+// again when it or its commit fails with a retryable error
+type retryingTransactor struct {
+	db    *gorm.DB
+	retry config.RetryConfig
+}
+
+// NewTransactor returns a Transactor running transactions on db and retrying
+// them as retry says
+func NewTransactor(db *gorm.DB, retry config.RetryConfig) Transactor {
+	return &retryingTransactor{db: db, retry: retry}
+}
+
+// Transaction implements Transactor. fn may run more than once, so it must
+// not have effects outside tx.
+func (t *retryingTransactor) Transaction(ctx context.Context, fn func(tx *gorm.DB) error) error {
+	return withRetry(ctx, t.retry.MaxAttempts, t.retry.BaseDelay, func() error {
+		return t.db.WithContext(ctx).Transaction(fn)
+	})
+}
+
+// Transaction runs fn in a transaction, running the whole transaction again
+// when it or its commit fails with a retryable error, as configured by
+// DB_RETRY_MAX_ATTEMPTS and DB_RETRY_BASE_DELAY_MS
+func (d *Database) Transaction(ctx context.Context, fn func(tx *gorm.DB) error) error {
+	return NewTransactor(d.DB, d.retry).Transaction(ctx, fn)
+}
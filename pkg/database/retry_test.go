@@ -0,0 +1,150 @@
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/your-username/go-clean-architecture/config"
+	"github.com/your-username/go-clean-architecture/pkg/database/dbtest"
+	"gorm.io/gorm"
+)
+
+// sqlStateError is a driver error carrying a Postgres SQLSTATE
+type sqlStateError string
+
+func (e sqlStateError) Error() string    { return "pq: error " + string(e) }
+func (e sqlStateError) SQLState() string { return string(e) }
+
+// fakeCall fails with the next of errs on each call, succeeding once they run out
+type fakeCall struct {
+	errs  []error
+	calls int
+}
+
+func (f *fakeCall) call() error {
+	f.calls++
+	if f.calls > len(f.errs) {
+		return nil
+	}
+	return f.errs[f.calls-1]
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "serialization failure", err: sqlStateError("40001"), want: true},
+		{name: "deadlock", err: sqlStateError("40P01"), want: true},
+		{name: "wrapped serialization failure", err: fmt.Errorf("commit: %w", sqlStateError("40001")), want: true},
+		{name: "unique violation", err: sqlStateError("23505"), want: false},
+		{name: "bad connection", err: driver.ErrBadConn, want: true},
+		{name: "other error", err: errors.New("syntax error"), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRetryable(tt.err); got != tt.want {
+				t.Errorf("IsRetryable(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithRetry(t *testing.T) {
+	serialization := sqlStateError("40001")
+	permanent := errors.New("constraint violated")
+
+	tests := []struct {
+		name        string
+		maxAttempts int
+		errs        []error
+		want        error
+		wantCalls   int
+	}{
+		{name: "succeeds at once", maxAttempts: 3, wantCalls: 1},
+		{name: "retries transient errors", maxAttempts: 3, errs: []error{serialization, driver.ErrBadConn}, wantCalls: 3},
+		{name: "gives up on a non-retryable error", maxAttempts: 3, errs: []error{permanent}, want: permanent, wantCalls: 1},
+		{name: "gives up on a non-retryable error after a transient one", maxAttempts: 3, errs: []error{serialization, permanent}, want: permanent, wantCalls: 2},
+		{name: "runs out of attempts", maxAttempts: 3, errs: []error{serialization, serialization, serialization, serialization}, want: serialization, wantCalls: 3},
+		{name: "attempts below 1 call once", maxAttempts: 0, errs: []error{serialization}, want: serialization, wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fakeCall{errs: tt.errs}
+			err := withRetry(context.Background(), tt.maxAttempts, time.Millisecond, f.call)
+			if !errors.Is(err, tt.want) || (tt.want == nil && err != nil) {
+				t.Errorf("withRetry() error = %v, want %v", err, tt.want)
+			}
+			if f.calls != tt.wantCalls {
+				t.Errorf("withRetry() called fn %d times, want %d", f.calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestWithRetryStopsWhenContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	f := &fakeCall{errs: []error{sqlStateError("40001"), sqlStateError("40001")}}
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	// The backoff is far longer than the test, so only the cancellation ends it
+	err := withRetry(ctx, 3, time.Hour, f.call)
+	if !errors.Is(err, context.Canceled) || !errors.Is(err, sqlStateError("40001")) {
+		t.Errorf("withRetry() error = %v, want the last error joined with context.Canceled", err)
+	}
+	if f.calls != 1 {
+		t.Errorf("withRetry() called fn %d times, want 1", f.calls)
+	}
+}
+
+func TestWithRetryBacksOff(t *testing.T) {
+	f := &fakeCall{errs: []error{sqlStateError("40001"), sqlStateError("40001")}}
+
+	// Waits of at least 10ms and 20ms, half of each doubling delay plus jitter
+	start := time.Now()
+	if err := withRetry(context.Background(), 3, 20*time.Millisecond, f.call); err != nil {
+		t.Fatalf("withRetry() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Errorf("withRetry() took %s, want at least 30ms of backoff", elapsed)
+	}
+}
+
+func TestTransactorRetriesWholeTransaction(t *testing.T) {
+	db := dbtest.New(t, &pageRow{})
+	tx := NewTransactor(db, config.RetryConfig{MaxAttempts: 3, BaseDelay: time.Millisecond})
+
+	// The first attempt writes a row, then fails to commit with a
+	// serialization failure and is rolled back
+	attempts := 0
+	err := tx.Transaction(context.Background(), func(tx *gorm.DB) error {
+		attempts++
+		if err := tx.Create(&pageRow{}).Error; err != nil {
+			return err
+		}
+		if attempts == 1 {
+			return sqlStateError("40001")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Transaction() error = %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("Transaction() ran fn %d times, want 2", attempts)
+	}
+
+	var rows int64
+	db.Model(&pageRow{}).Count(&rows)
+	if rows != 1 {
+		t.Errorf("%d rows after the retried transaction, want 1", rows)
+	}
+}
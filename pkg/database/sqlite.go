@@ -0,0 +1,13 @@
+//go:build cgo
+
+package database
+
+import (
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// openSQLite returns the SQLite dialector, which needs cgo
+func openSQLite(dsn string) (gorm.Dialector, error) {
+	return sqlite.Open(dsn), nil
+}
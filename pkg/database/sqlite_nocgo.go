@@ -0,0 +1,14 @@
+//go:build !cgo
+
+package database
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// openSQLite reports that SQLite is unavailable, as its driver needs cgo
+func openSQLite(string) (gorm.Dialector, error) {
+	return nil, errors.New("DB_DRIVER=sqlite needs a binary built with CGO_ENABLED=1")
+}
@@ -0,0 +1,79 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/your-username/go-clean-architecture/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// spanKey is the statement setting holding the span of a running query
+const spanKey = "app:span"
+
+// RegisterTracing records a client span for every query, as a child of the
+// span carried by the statement context. Only the SQL text with placeholders
+// is recorded, never the bound values.
+func RegisterTracing(db *gorm.DB) error {
+	system := db.Dialector.Name()
+	if system == "postgres" {
+		system = semconv.DBSystemNamePostgreSQL.Value.AsString()
+	}
+
+	start := func(tx *gorm.DB) {
+		ctx := tx.Statement.Context
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		ctx, span := tracing.Tracer().Start(ctx, "gorm",
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(semconv.DBSystemNameKey.String(system)),
+		)
+		tx.Statement.Context = ctx
+		tx.InstanceSet(spanKey, span)
+	}
+	end := func(tx *gorm.DB) {
+		value, ok := tx.InstanceGet(spanKey)
+		if !ok {
+			return
+		}
+		span := value.(trace.Span)
+		defer span.End()
+
+		query := tx.Statement.SQL.String()
+		operation, _, _ := strings.Cut(strings.TrimSpace(query), " ")
+		operation = strings.ToUpper(operation)
+		span.SetName(strings.TrimSpace(operation + " " + tx.Statement.Table))
+		span.SetAttributes(
+			semconv.DBOperationNameKey.String(operation),
+			semconv.DBCollectionNameKey.String(tx.Statement.Table),
+			semconv.DBQueryTextKey.String(query),
+			attribute.Int64("db.response.affected_rows", tx.Statement.RowsAffected),
+		)
+		if tx.Error != nil && !errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+			span.RecordError(tx.Error)
+			span.SetStatus(codes.Error, tx.Error.Error())
+		}
+	}
+
+	cb := db.Callback()
+	return errors.Join(
+		cb.Create().Before("gorm:create").Register("app:trace_start", start),
+		cb.Create().After("gorm:after_create").Register("app:trace_end", end),
+		cb.Query().Before("gorm:query").Register("app:trace_start", start),
+		cb.Query().After("gorm:after_query").Register("app:trace_end", end),
+		cb.Update().Before("gorm:update").Register("app:trace_start", start),
+		cb.Update().After("gorm:after_update").Register("app:trace_end", end),
+		cb.Delete().Before("gorm:delete").Register("app:trace_start", start),
+		cb.Delete().After("gorm:after_delete").Register("app:trace_end", end),
+		cb.Row().Before("gorm:row").Register("app:trace_start", start),
+		cb.Row().After("gorm:row").Register("app:trace_end", end),
+		cb.Raw().Before("gorm:raw").Register("app:trace_start", start),
+		cb.Raw().After("gorm:raw").Register("app:trace_end", end),
+	)
+}
@@ -0,0 +1,104 @@
+// Package events dispatches in-process events to the handlers subscribed to
+// them, so use cases can announce what happened without knowing what reacts
+// to it
+package events
+
+import (
+	"context"
+	"runtime/debug"
+	"sync"
+
+	"github.com/your-username/go-clean-architecture/pkg/logger"
+)
+
+// Event is something that happened, identified by its type
+type Event interface {
+	EventType() string
+}
+
+// Handler reacts to an event. Its error is logged, the publisher never sees it.
+type Handler func(ctx context.Context, event Event) error
+
+// Publisher publishes events
+type Publisher interface {
+	Publish(ctx context.Context, event Event)
+}
+
+// Bus dispatches events asynchronously: every handler subscribed to the
+// event's type runs in its own goroutine, and a handler that fails or panics
+// affects neither the publisher nor the other handlers
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+	closed   bool
+	running  sync.WaitGroup
+}
+
+// NewBus creates a new event bus
+func NewBus() *Bus {
+	return &Bus{handlers: make(map[string][]Handler)}
+}
+
+// Subscribe registers handler for events of the given type. Subscribers are
+// meant to be registered at startup, before events are published.
+func (b *Bus) Subscribe(eventType string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.handlers[eventType] = append(b.handlers[eventType], handler)
+}
+
+// Publish dispatches event to its handlers without waiting for them. Handlers
+// get ctx without its cancellation, so they keep request values such as the
+// audit actor but may outlive the request. Events published after Close are
+// dropped.
+func (b *Bus) Publish(ctx context.Context, event Event) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.closed {
+		logger.Warnf("Event %s dropped, event bus closed", event.EventType())
+		return
+	}
+
+	ctx = context.WithoutCancel(ctx)
+	for _, handler := range b.handlers[event.EventType()] {
+		b.running.Add(1)
+		go b.dispatch(ctx, event, handler)
+	}
+}
+
+// dispatch runs a handler, logging its error or panic
+func (b *Bus) dispatch(ctx context.Context, event Event, handler Handler) {
+	defer b.running.Done()
+	defer func() {
+		if r := recover(); r != nil {
+			logger.WithContext(ctx).Errorf("Event handler for %s panicked: %v\n%s", event.EventType(), r, debug.Stack())
+		}
+	}()
+
+	if err := handler(ctx, event); err != nil {
+		logger.WithContext(ctx).Warnf("Event handler for %s failed: %v", event.EventType(), err)
+	}
+}
+
+// Close stops accepting events and waits for running handlers to finish, or
+// for ctx to be done
+func (b *Bus) Close(ctx context.Context) error {
+	b.mu.Lock()
+	b.closed = true
+	b.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		b.running.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,131 @@
+// Package fieldset implements sparse fieldsets, letting clients request only
+// some JSON fields of a response
+package fieldset
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-username/go-clean-architecture/pkg/apperrors"
+)
+
+// QueryParam is the query parameter listing the requested fields
+const QueryParam = "fields"
+
+// alwaysIncluded fields are returned even when not requested, keeping
+// resources addressable
+var alwaysIncluded = []string{"id"}
+
+// Bind reads the comma-separated fields query parameter and validates it
+// against the JSON fields of T. A nil result means all fields were requested.
+// Unknown fields are rejected with a 400 error.
+func Bind[T any](c *gin.Context) ([]string, error) {
+	raw := strings.TrimSpace(c.Query(QueryParam))
+	if raw == "" {
+		return nil, nil
+	}
+
+	known := jsonFields(reflect.TypeOf((*T)(nil)).Elem())
+
+	fields := append([]string{}, alwaysIncluded...)
+	var unknown []string
+	for _, field := range strings.Split(raw, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		if !known[field] {
+			unknown = append(unknown, field)
+			continue
+		}
+		fields = append(fields, field)
+	}
+
+	if len(unknown) > 0 {
+		return nil, apperrors.NewAppError(http.StatusBadRequest, apperrors.SlugBadRequest,
+			fmt.Sprintf("Unknown fields: %s", strings.Join(unknown, ", ")), nil)
+	}
+	return fields, nil
+}
+
+// Select returns v with only the given JSON fields, or v itself when fields
+// is nil
+func Select[T any](v T, fields []string) (interface{}, error) {
+	if fields == nil {
+		return v, nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+
+	selected := make(map[string]json.RawMessage, len(fields))
+	for _, field := range fields {
+		if value, ok := all[field]; ok {
+			selected[field] = value
+		}
+	}
+	return selected, nil
+}
+
+// SelectAll applies Select to each item
+func SelectAll[T any](items []T, fields []string) ([]interface{}, error) {
+	result := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		selected, err := Select(item, fields)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, selected)
+	}
+	return result, nil
+}
+
+// jsonFields returns the JSON field names of a struct type, including those
+// of embedded structs
+func jsonFields(t reflect.Type) map[string]bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	fields := make(map[string]bool)
+	if t.Kind() != reflect.Struct {
+		return fields
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			for embedded := range jsonFields(field.Type) {
+				fields[embedded] = true
+			}
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = true
+	}
+	return fields
+}
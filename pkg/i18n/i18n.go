@@ -0,0 +1,90 @@
+// Package i18n negotiates the response locale and translates response
+// messages from a central catalog
+package i18n
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Supported locales
+const (
+	LocaleEnglish    = "en"
+	LocaleIndonesian = "id"
+	DefaultLocale    = LocaleEnglish
+)
+
+// Key identifies a message in the catalog. Text that is not a key is used
+// as is, so messages that have no translation yet still read correctly.
+type Key string
+
+// T returns the message for key in locale, falling back to English and then
+// to the key itself
+func T(locale string, key Key) string {
+	if msg, ok := catalog[locale][key]; ok {
+		return msg
+	}
+	if msg, ok := catalog[DefaultLocale][key]; ok {
+		return msg
+	}
+	return string(key)
+}
+
+// Error translates the message of an error with the given slug. Only the
+// slug's stock message is translated; messages written for one occurrence
+// are returned as is.
+func Error(locale, slug, message string) string {
+	key := Key(slug)
+	if stock, ok := catalog[DefaultLocale][key]; !ok || stock != message {
+		return message
+	}
+	return T(locale, key)
+}
+
+// FromContext returns the locale negotiated from the request's
+// Accept-Language header
+func FromContext(c *gin.Context) string {
+	return ParseLocale(c.GetHeader("Accept-Language"))
+}
+
+// ParseLocale picks the best supported locale from an Accept-Language header,
+// falling back to English
+func ParseLocale(acceptLanguage string) string {
+	type candidate struct {
+		tag string
+		q   float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if tag == "" {
+			continue
+		}
+
+		q := 1.0
+		if _, value, found := strings.Cut(params, "q="); found {
+			if parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+				q = parsed
+			}
+		}
+
+		// Only the primary subtag matters, e.g. "id-ID" -> "id"
+		primary, _, _ := strings.Cut(tag, "-")
+		candidates = append(candidates, candidate{tag: strings.ToLower(primary), q: q})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].q > candidates[j].q
+	})
+
+	for _, c := range candidates {
+		if _, ok := catalog[c.tag]; ok && c.q > 0 {
+			return c.tag
+		}
+	}
+	return DefaultLocale
+}
@@ -0,0 +1,173 @@
+package i18n
+
+import "github.com/your-username/go-clean-architecture/pkg/apperrors"
+
+// Response message keys
+const (
+	MsgServiceRunning       Key = "service.running"
+	MsgServiceReady         Key = "service.ready"
+	MsgDataRetrieved        Key = "data.retrieved"
+	MsgValidationFailed     Key = "validation.failed"
+	MsgUserRegistered       Key = "user.registered"
+	MsgLoginSuccessful      Key = "auth.login"
+	MsgTokenIntrospected    Key = "auth.introspected"
+	MsgLoggedOutAll         Key = "auth.logged_out_all"
+	MsgUserRetrieved        Key = "user.retrieved"
+	MsgUsersRetrieved       Key = "users.retrieved"
+	MsgUserUpdated          Key = "user.updated"
+	MsgUserDeleted          Key = "user.deleted"
+	MsgUsersDeleted         Key = "users.deleted"
+	MsgUserRestored         Key = "user.restored"
+	MsgUserPurged           Key = "user.purged"
+	MsgUserRoleChanged      Key = "user.role_changed"
+	MsgUserStatusChanged    Key = "user.status_changed"
+	MsgUserActivated        Key = "user.activated"
+	MsgUserDeactivated      Key = "user.deactivated"
+	MsgAvatarUploaded       Key = "user.avatar_uploaded"
+	MsgPermissionsRetrieved Key = "permissions.retrieved"
+	MsgAuditLogsRetrieved   Key = "audit_logs.retrieved"
+
+	MsgAuthHeaderRequired    Key = "auth.header_required"
+	MsgAuthHeaderInvalid     Key = "auth.header_invalid"
+	MsgTokenInvalid          Key = "auth.token_invalid"
+	MsgTokenIssueTimeMissing Key = "auth.token_issue_time_missing"
+	MsgUserRoleMissing       Key = "auth.role_missing"
+	MsgUserNotAuthenticated  Key = "auth.not_authenticated"
+	MsgPermissionDenied      Key = "auth.permission_denied"
+	MsgRequestTimedOut       Key = "request.timed_out"
+	MsgRequestBodyUnreadable Key = "request.body_unreadable"
+	MsgRequestUndocumented   Key = "request.undocumented"
+)
+
+// catalog holds the messages per locale. Error messages are keyed by their
+// apperrors slug and the English entries match the apperrors messages.
+var catalog = map[string]map[Key]string{
+	LocaleEnglish: {
+		MsgServiceRunning:       "Service is running",
+		MsgServiceReady:         "Service is ready",
+		MsgDataRetrieved:        "Data retrieved successfully",
+		MsgValidationFailed:     "Validation failed",
+		MsgUserRegistered:       "User registered successfully",
+		MsgLoginSuccessful:      "Login successful",
+		MsgTokenIntrospected:    "Token introspected successfully",
+		MsgLoggedOutAll:         "Logged out of all sessions",
+		MsgUserRetrieved:        "User retrieved successfully",
+		MsgUsersRetrieved:       "Users retrieved successfully",
+		MsgUserUpdated:          "User updated successfully",
+		MsgUserDeleted:          "User deleted successfully",
+		MsgUsersDeleted:         "Users deleted successfully",
+		MsgUserRestored:         "User restored successfully",
+		MsgUserPurged:           "User purged successfully",
+		MsgUserRoleChanged:      "User role changed successfully",
+		MsgUserStatusChanged:    "User status changed successfully",
+		MsgUserActivated:        "User activated successfully",
+		MsgUserDeactivated:      "User deactivated successfully",
+		MsgAvatarUploaded:       "Avatar uploaded successfully",
+		MsgPermissionsRetrieved: "Permissions retrieved successfully",
+		MsgAuditLogsRetrieved:   "Audit logs retrieved successfully",
+
+		MsgAuthHeaderRequired:    "Authorization header is required",
+		MsgAuthHeaderInvalid:     "Invalid authorization header format",
+		MsgTokenInvalid:          "Invalid or expired token",
+		MsgTokenIssueTimeMissing: "Token issue time not found",
+		MsgUserRoleMissing:       "User role not found",
+		MsgUserNotAuthenticated:  "User not authenticated",
+		MsgPermissionDenied:      "You don't have permission to access this resource",
+		MsgRequestTimedOut:       "Request timed out",
+		MsgRequestBodyUnreadable: "Failed to read request body",
+		MsgRequestUndocumented:   "Request does not match the API documentation",
+
+		apperrors.SlugNotFound:          "Resource not found",
+		apperrors.SlugBadRequest:        "Bad request",
+		apperrors.SlugUnauthorized:      "Unauthorized",
+		apperrors.SlugForbidden:         "Forbidden",
+		apperrors.SlugConflict:          "Resource conflict",
+		apperrors.SlugInternalServer:    "Internal server error",
+		apperrors.SlugValidation:        "Validation error",
+		apperrors.SlugInvalidCredential: "Invalid email or password",
+		apperrors.SlugUserNotActive:     "User account is not active",
+		apperrors.SlugUserPending:       "User account is pending verification",
+		apperrors.SlugUserSuspended:     "User account is suspended",
+		apperrors.SlugUserBanned:        "User account is banned",
+		apperrors.SlugSelfStatusChange:  "You cannot change the status of your own account",
+		apperrors.SlugLastAdmin:         "The last active admin cannot be demoted or deleted",
+		apperrors.SlugEmailTaken:        "Email is already registered",
+		apperrors.SlugUserNotFound:      "User not found",
+		apperrors.SlugConfirmMismatch:   "Confirmation does not match",
+		apperrors.SlugIdempotencyBusy:   "A request with this idempotency key is already in progress",
+		apperrors.SlugIdempotencyReuse:  "Idempotency key was already used with a different request",
+		apperrors.SlugPayloadTooLarge:   "Request body too large",
+		apperrors.SlugInvalidImage:      "File must be a JPEG, PNG or GIF image",
+		apperrors.SlugQueryTimeout:      "The database did not respond in time",
+		apperrors.SlugTokenRevoked:      "Token has been revoked",
+		apperrors.SlugVersionConflict:   "Resource was modified by another request, reload it and retry",
+		apperrors.SlugReauthRequired:    "Re-authentication required, log in again to continue",
+		apperrors.SlugShuttingDown:      "Service is shutting down, retry the request",
+		apperrors.SlugMalformedBody:     "Malformed request body",
+		apperrors.SlugSelfDelete:        "You cannot delete your own account in a batch",
+	},
+	LocaleIndonesian: {
+		MsgServiceRunning:       "Layanan berjalan",
+		MsgServiceReady:         "Layanan siap",
+		MsgDataRetrieved:        "Data berhasil diambil",
+		MsgValidationFailed:     "Validasi gagal",
+		MsgUserRegistered:       "Pengguna berhasil didaftarkan",
+		MsgLoginSuccessful:      "Berhasil masuk",
+		MsgTokenIntrospected:    "Token berhasil diperiksa",
+		MsgLoggedOutAll:         "Berhasil keluar dari semua sesi",
+		MsgUserRetrieved:        "Pengguna berhasil diambil",
+		MsgUsersRetrieved:       "Daftar pengguna berhasil diambil",
+		MsgUserUpdated:          "Pengguna berhasil diperbarui",
+		MsgUserDeleted:          "Pengguna berhasil dihapus",
+		MsgUsersDeleted:         "Para pengguna berhasil dihapus",
+		MsgUserRestored:         "Pengguna berhasil dipulihkan",
+		MsgUserPurged:           "Pengguna berhasil dihapus permanen",
+		MsgUserRoleChanged:      "Peran pengguna berhasil diubah",
+		MsgUserStatusChanged:    "Status pengguna berhasil diubah",
+		MsgUserActivated:        "Pengguna berhasil diaktifkan",
+		MsgUserDeactivated:      "Pengguna berhasil dinonaktifkan",
+		MsgAvatarUploaded:       "Avatar berhasil diunggah",
+		MsgPermissionsRetrieved: "Izin berhasil diambil",
+		MsgAuditLogsRetrieved:   "Log audit berhasil diambil",
+
+		MsgAuthHeaderRequired:    "Header Authorization wajib diisi",
+		MsgAuthHeaderInvalid:     "Format header Authorization tidak valid",
+		MsgTokenInvalid:          "Token tidak valid atau kedaluwarsa",
+		MsgTokenIssueTimeMissing: "Waktu terbit token tidak ditemukan",
+		MsgUserRoleMissing:       "Peran pengguna tidak ditemukan",
+		MsgUserNotAuthenticated:  "Pengguna belum terautentikasi",
+		MsgPermissionDenied:      "Anda tidak memiliki izin untuk mengakses sumber daya ini",
+		MsgRequestTimedOut:       "Waktu permintaan habis",
+		MsgRequestBodyUnreadable: "Gagal membaca isi permintaan",
+		MsgRequestUndocumented:   "Permintaan tidak sesuai dengan dokumentasi API",
+
+		apperrors.SlugNotFound:          "Sumber daya tidak ditemukan",
+		apperrors.SlugBadRequest:        "Permintaan tidak valid",
+		apperrors.SlugUnauthorized:      "Tidak terautentikasi",
+		apperrors.SlugForbidden:         "Akses ditolak",
+		apperrors.SlugConflict:          "Terjadi konflik sumber daya",
+		apperrors.SlugInternalServer:    "Terjadi kesalahan pada server",
+		apperrors.SlugValidation:        "Kesalahan validasi",
+		apperrors.SlugInvalidCredential: "Email atau kata sandi salah",
+		apperrors.SlugUserNotActive:     "Akun pengguna tidak aktif",
+		apperrors.SlugUserPending:       "Akun pengguna menunggu verifikasi",
+		apperrors.SlugUserSuspended:     "Akun pengguna ditangguhkan",
+		apperrors.SlugUserBanned:        "Akun pengguna diblokir",
+		apperrors.SlugSelfStatusChange:  "Anda tidak dapat mengubah status akun Anda sendiri",
+		apperrors.SlugLastAdmin:         "Admin aktif terakhir tidak dapat diturunkan atau dihapus",
+		apperrors.SlugEmailTaken:        "Email sudah terdaftar",
+		apperrors.SlugUserNotFound:      "Pengguna tidak ditemukan",
+		apperrors.SlugConfirmMismatch:   "Konfirmasi tidak cocok",
+		apperrors.SlugIdempotencyBusy:   "Permintaan dengan kunci idempotensi ini sedang diproses",
+		apperrors.SlugIdempotencyReuse:  "Kunci idempotensi sudah digunakan untuk permintaan yang berbeda",
+		apperrors.SlugPayloadTooLarge:   "Isi permintaan terlalu besar",
+		apperrors.SlugInvalidImage:      "Berkas harus berupa gambar JPEG, PNG, atau GIF",
+		apperrors.SlugQueryTimeout:      "Basis data tidak merespons tepat waktu",
+		apperrors.SlugTokenRevoked:      "Token telah dicabut",
+		apperrors.SlugVersionConflict:   "Sumber daya telah diubah oleh permintaan lain, muat ulang lalu coba lagi",
+		apperrors.SlugReauthRequired:    "Autentikasi ulang diperlukan, masuk kembali untuk melanjutkan",
+		apperrors.SlugShuttingDown:      "Layanan sedang dimatikan, coba lagi permintaan ini",
+		apperrors.SlugMalformedBody:     "Isi permintaan tidak valid",
+		apperrors.SlugSelfDelete:        "Anda tidak dapat menghapus akun Anda sendiri secara massal",
+	},
+}
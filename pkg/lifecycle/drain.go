@@ -0,0 +1,60 @@
+package lifecycle
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+)
+
+// Drain tells long-lived handlers, such as event streams, to wind down when
+// the server starts shutting down, and counts the requests in flight
+type Drain struct {
+	ctx          context.Context
+	cancel       context.CancelFunc
+	inFlight     atomic.Int64
+	shuttingDown atomic.Bool
+}
+
+// NewDrain creates a new drain
+func NewDrain() *Drain {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Drain{ctx: ctx, cancel: cancel}
+}
+
+// ShutdownCtx returns a context cancelled once draining starts. Long-lived
+// handlers select on it to end their response cleanly rather than be cut
+// off at the shutdown deadline.
+func (d *Drain) ShutdownCtx() context.Context {
+	return d.ctx
+}
+
+// BeginShutdown marks the server as shutting down, as soon as the shutdown
+// signal arrives, so it can turn away new requests while the load balancer
+// stops routing to it
+func (d *Drain) BeginShutdown() {
+	d.shuttingDown.Store(true)
+}
+
+// ShuttingDown reports whether BeginShutdown was called
+func (d *Drain) ShuttingDown() bool {
+	return d.shuttingDown.Load()
+}
+
+// Start starts draining. It is safe to call more than once.
+func (d *Drain) Start() {
+	d.cancel()
+}
+
+// InFlight returns the number of requests being served
+func (d *Drain) InFlight() int64 {
+	return d.inFlight.Load()
+}
+
+// Track wraps next to count the requests it is serving
+func (d *Drain) Track(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		d.inFlight.Add(1)
+		defer d.inFlight.Add(-1)
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,85 @@
+// Package lifecycle coordinates the shutdown of application components
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/your-username/go-clean-architecture/pkg/logger"
+)
+
+// hook is a registered shutdown function
+type hook struct {
+	name    string
+	timeout time.Duration
+	fn      func(ctx context.Context) error
+}
+
+// Coordinator runs shutdown hooks. Hooks run in reverse registration order,
+// like deferred calls, so a component registered after its dependencies is
+// stopped before them.
+type Coordinator struct {
+	mu    sync.Mutex
+	hooks []hook
+}
+
+// New creates a new coordinator
+func New() *Coordinator {
+	return &Coordinator{}
+}
+
+// OnShutdown registers fn to run on shutdown. Its context is cancelled after
+// timeout, or when the context passed to Shutdown is done.
+func (c *Coordinator) OnShutdown(name string, timeout time.Duration, fn func(ctx context.Context) error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.hooks = append(c.hooks, hook{name: name, timeout: timeout, fn: fn})
+}
+
+// OnShutdownClose registers a Close style function that takes no context.
+// Close returns once timeout expires even if fn is still running.
+func (c *Coordinator) OnShutdownClose(name string, timeout time.Duration, fn func() error) {
+	c.OnShutdown(name, timeout, func(ctx context.Context) error {
+		done := make(chan error, 1)
+		go func() { done <- fn() }()
+
+		select {
+		case err := <-done:
+			return err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+}
+
+// Shutdown runs every hook, even if earlier ones fail, and returns their
+// errors joined
+func (c *Coordinator) Shutdown(ctx context.Context) error {
+	c.mu.Lock()
+	hooks := c.hooks
+	c.hooks = nil
+	c.mu.Unlock()
+
+	var errs []error
+	for i := len(hooks) - 1; i >= 0; i-- {
+		h := hooks[i]
+
+		hookCtx, cancel := context.WithTimeout(ctx, h.timeout)
+		start := time.Now()
+		err := h.fn(hookCtx)
+		cancel()
+
+		if err != nil {
+			logger.Errorf("Shutdown of %s failed after %s: %v", h.name, time.Since(start).Round(time.Millisecond), err)
+			errs = append(errs, fmt.Errorf("%s: %w", h.name, err))
+			continue
+		}
+		logger.Infof("Shut down %s in %s", h.name, time.Since(start).Round(time.Millisecond))
+	}
+
+	return errors.Join(errs...)
+}
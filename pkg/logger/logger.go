@@ -1,30 +1,75 @@
 package logger
 
 import (
+	"context"
+	"io"
 	"os"
 	"time"
 
+	"github.com/getsentry/sentry-go"
 	"github.com/sirupsen/logrus"
+	"github.com/your-username/go-clean-architecture/config"
+	"gopkg.in/natefinch/lumberjack.v2"
 )
 
 var Log *logrus.Logger
 
-// InitLogger initializes the logrus logger
+// fileWriter is the rotating log file, if file output is enabled
+var fileWriter *lumberjack.Logger
+
+// sentryEnabled reports whether the Sentry hook is registered
+var sentryEnabled bool
+
+// InitLogger initializes the logrus logger writing to stdout at debug or info level
 func InitLogger(debug bool) {
+	level := "info"
+	if debug {
+		level = "debug"
+	}
+	InitLoggerWithConfig(&config.LogConfig{Level: level, Stdout: true})
+}
+
+// InitLoggerWithConfig initializes the logrus logger, optionally writing to a
+// rotating log file in addition to (or instead of) stdout, and forwarding
+// errors to Sentry when a DSN is configured
+func InitLoggerWithConfig(cfg *config.LogConfig) {
+	Close()
 	Log = logrus.New()
 
-	// Set output to stdout
-	Log.SetOutput(os.Stdout)
+	// Set output to stdout and/or a rotating file
+	var writers []io.Writer
+	if cfg.Stdout || cfg.File == "" {
+		writers = append(writers, os.Stdout)
+	}
+	if cfg.File != "" {
+		fileWriter = &lumberjack.Logger{
+			Filename:   cfg.File,
+			MaxSize:    cfg.MaxSizeMB,
+			MaxBackups: cfg.MaxBackups,
+			MaxAge:     cfg.MaxAgeDays,
+			Compress:   cfg.Compress,
+		}
+		writers = append(writers, fileWriter)
+	}
+	Log.SetOutput(io.MultiWriter(writers...))
 
-	// Set log level
-	if debug {
-		Log.SetLevel(logrus.DebugLevel)
-	} else {
-		Log.SetLevel(logrus.InfoLevel)
+	// Set log level, defaulting to info on an invalid value
+	level, levelErr := logrus.ParseLevel(cfg.Level)
+	if levelErr != nil {
+		level = logrus.InfoLevel
+	}
+	Log.SetLevel(level)
+
+	// Set JSON formatter for production, text for development, unless overridden
+	format := cfg.Format
+	if format == "" {
+		format = "text"
+		if os.Getenv("APP_ENV") == "production" {
+			format = "json"
+		}
 	}
 
-	// Set JSON formatter for production, text for development
-	if os.Getenv("APP_ENV") == "production" {
+	if format == "json" {
 		Log.SetFormatter(&logrus.JSONFormatter{
 			TimestampFormat: time.RFC3339,
 		})
@@ -32,9 +77,49 @@ func InitLogger(debug bool) {
 		Log.SetFormatter(&logrus.TextFormatter{
 			FullTimestamp:   true,
 			TimestampFormat: "2006-01-02 15:04:05",
-			ForceColors:     true,
+			ForceColors:     cfg.File == "",
 		})
 	}
+
+	// Forward errors to Sentry, no-op without a DSN
+	if cfg.SentryDSN != "" {
+		hook, err := initSentry(cfg.SentryDSN, cfg.SentryEnvironment)
+		if err != nil {
+			Log.Warnf("Failed to initialize Sentry: %v", err)
+		} else {
+			Log.AddHook(hook)
+			sentryEnabled = true
+		}
+	}
+
+	if levelErr != nil {
+		Log.Warnf("Invalid log level %q, defaulting to info", cfg.Level)
+	}
+}
+
+// SetLevel changes the log level of the running logger
+func SetLevel(level string) error {
+	parsed, err := logrus.ParseLevel(level)
+	if err != nil {
+		return err
+	}
+	Log.SetLevel(parsed)
+	return nil
+}
+
+// Close flushes pending Sentry events and closes the log file, if any
+func Close() error {
+	if sentryEnabled {
+		sentry.Flush(sentryFlushTimeout)
+		sentryEnabled = false
+	}
+
+	if fileWriter == nil {
+		return nil
+	}
+	err := fileWriter.Close()
+	fileWriter = nil
+	return err
 }
 
 // Info logs info level message
@@ -87,6 +172,29 @@ func Fatalf(format string, args ...interface{}) {
 	Log.Fatalf(format, args...)
 }
 
+// requestIDKey is the context key for the request ID
+type requestIDKey struct{}
+
+// ContextWithRequestID returns a copy of ctx carrying the request ID
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext returns the request ID stored in ctx, if any
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// WithContext creates a log entry carrying the request ID from ctx, if any
+func WithContext(ctx context.Context) *logrus.Entry {
+	entry := logrus.NewEntry(Log).WithContext(ctx)
+	if requestID := RequestIDFromContext(ctx); requestID != "" {
+		entry = entry.WithField("request_id", requestID)
+	}
+	return entry
+}
+
 // WithField creates a log entry with a field
 func WithField(key string, value interface{}) *logrus.Entry {
 	return Log.WithField(key, value)
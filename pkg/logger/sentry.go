@@ -0,0 +1,85 @@
+package logger
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/sirupsen/logrus"
+)
+
+// sentryFlushTimeout bounds how long fatal entries wait for delivery
+const sentryFlushTimeout = 2 * time.Second
+
+// sentryHook forwards error level entries to Sentry
+type sentryHook struct{}
+
+// initSentry initializes the Sentry client and returns its logrus hook
+func initSentry(dsn, environment string) (logrus.Hook, error) {
+	if err := sentry.Init(sentry.ClientOptions{
+		Dsn:              dsn,
+		Environment:      environment,
+		AttachStacktrace: true,
+	}); err != nil {
+		return nil, err
+	}
+	return &sentryHook{}, nil
+}
+
+// Levels implements logrus.Hook
+func (h *sentryHook) Levels() []logrus.Level {
+	return []logrus.Level{logrus.PanicLevel, logrus.FatalLevel, logrus.ErrorLevel}
+}
+
+// Fire implements logrus.Hook
+func (h *sentryHook) Fire(entry *logrus.Entry) error {
+	event := sentry.NewEvent()
+	event.Level = sentryLevel(entry.Level)
+	event.Message = entry.Message
+	event.Timestamp = entry.Time
+
+	for key, value := range entry.Data {
+		switch key {
+		case logrus.ErrorKey:
+			if err, ok := value.(error); ok {
+				event.Exception = []sentry.Exception{{
+					Type:       reflect.TypeOf(err).String(),
+					Value:      err.Error(),
+					Stacktrace: sentry.ExtractStacktrace(err),
+				}}
+				continue
+			}
+		case "request_id":
+			event.Tags["request_id"] = fmt.Sprint(value)
+			continue
+		}
+		event.Extra[key] = value
+	}
+
+	// Attach the current stack when the error did not carry one
+	if len(event.Exception) == 0 || event.Exception[0].Stacktrace == nil {
+		event.Threads = []sentry.Thread{{
+			Stacktrace: sentry.NewStacktrace(),
+			Current:    true,
+			Crashed:    entry.Level <= logrus.FatalLevel,
+		}}
+	}
+
+	sentry.CaptureEvent(event)
+
+	if entry.Level <= logrus.FatalLevel {
+		sentry.Flush(sentryFlushTimeout)
+	}
+	return nil
+}
+
+// sentryLevel maps logrus levels to Sentry levels
+func sentryLevel(level logrus.Level) sentry.Level {
+	switch level {
+	case logrus.PanicLevel, logrus.FatalLevel:
+		return sentry.LevelFatal
+	default:
+		return sentry.LevelError
+	}
+}
@@ -0,0 +1,76 @@
+package mail
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"github.com/your-username/go-clean-architecture/pkg/logger"
+)
+
+const (
+	// logBodyPreview is the number of body characters logged per email
+	logBodyPreview = 200
+	// logSentCapacity is the number of recent emails kept for inspection
+	logSentCapacity = 100
+)
+
+// LogSender logs emails instead of delivering them, for local development
+// and CI. The most recent emails are kept so tests can assert on them.
+type LogSender struct {
+	mu   sync.Mutex
+	sent []EmailData
+}
+
+// NewLogSender creates a new log sender
+func NewLogSender() *LogSender {
+	return &LogSender{}
+}
+
+// Send logs the email and records it
+func (s *LogSender) Send(ctx context.Context, data EmailData) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	preview := []rune(data.Body)
+	if len(preview) > logBodyPreview {
+		preview = append(preview[:logBodyPreview], '…')
+	}
+
+	attachments := len(data.Attachments) + len(data.InlineAttachments)
+	logger.WithFields(logrus.Fields{
+		"to":          data.To,
+		"cc":          data.CC,
+		"bcc":         data.BCC,
+		"subject":     data.Subject,
+		"html":        data.IsHTML,
+		"attachments": attachments,
+		"body":        string(preview),
+	}).Info("Email not sent, mail driver is log")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.sent) == logSentCapacity {
+		s.sent = append(s.sent[:0], s.sent[1:]...)
+	}
+	s.sent = append(s.sent, data)
+	return nil
+}
+
+// Sent returns the recorded emails, oldest first
+func (s *LogSender) Sent() []EmailData {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]EmailData(nil), s.sent...)
+}
+
+// Reset forgets the recorded emails
+func (s *LogSender) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sent = nil
+}
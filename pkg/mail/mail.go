@@ -1,30 +1,29 @@
 package mail
 
 import (
-	"crypto/tls"
+	"context"
+	"fmt"
+	"html/template"
+	"io"
+	"mime"
+	"path/filepath"
 
 	"github.com/your-username/go-clean-architecture/config"
-	"github.com/your-username/go-clean-architecture/pkg/logger"
 	"gopkg.in/gomail.v2"
 )
 
-// Mailer handles email sending
-type Mailer struct {
-	dialer   *gomail.Dialer
-	from     string
-	fromName string
-}
-
-// NewMailer creates a new mailer instance
-func NewMailer(cfg *config.SMTPConfig) *Mailer {
-	dialer := gomail.NewDialer(cfg.Host, cfg.Port, cfg.Username, cfg.Password)
-	dialer.TLSConfig = &tls.Config{InsecureSkipVerify: true}
+// Mail drivers
+const (
+	DriverSMTP     = "smtp"
+	DriverSendGrid = "sendgrid"
+	DriverSES      = "ses"
+	DriverLog      = "log"
+)
 
-	return &Mailer{
-		dialer:   dialer,
-		from:     cfg.From,
-		fromName: cfg.FromName,
-	}
+// Sender sends an email through a concrete provider. Send gives up when ctx
+// is done and returns its error.
+type Sender interface {
+	Send(ctx context.Context, data EmailData) error
 }
 
 // EmailData holds email data
@@ -36,14 +35,97 @@ type EmailData struct {
 	Attachments []string
 	CC          []string
 	BCC         []string
+
+	// InlineAttachments are attached from memory, e.g. generated PDFs
+	InlineAttachments []InlineAttachment
+}
+
+// InlineAttachment is an attachment held in memory rather than on disk
+type InlineAttachment struct {
+	Filename    string
+	ContentType string
+	Content     []byte
+}
+
+// contentType returns the attachment content type, guessing from the filename if unset
+func (a InlineAttachment) contentType() string {
+	if a.ContentType != "" {
+		return a.ContentType
+	}
+	if ct := mime.TypeByExtension(filepath.Ext(a.Filename)); ct != "" {
+		return ct
+	}
+	return "application/octet-stream"
+}
+
+// NewMailer creates the Sender selected by the MAIL_DRIVER configuration
+func NewMailer(cfg *config.Config) (Sender, error) {
+	switch cfg.Mail.Driver {
+	case "", DriverSMTP:
+		return NewSMTPSender(cfg.Mail.From, cfg.Mail.FromName, append([]config.SMTPConfig{cfg.SMTP}, cfg.SMTPFallbacks...)...), nil
+	case DriverSendGrid:
+		return NewSendGridSender(cfg.Mail.From, cfg.Mail.FromName, &cfg.Mail.SendGrid), nil
+	case DriverSES:
+		return NewSESSender(cfg.Mail.From, cfg.Mail.FromName, &cfg.Mail.SES)
+	case DriverLog:
+		return NewLogSender(), nil
+	default:
+		return nil, fmt.Errorf("unknown mail driver: %s", cfg.Mail.Driver)
+	}
+}
+
+// Mailer wraps a Sender with convenience helpers and HTML templates
+type Mailer struct {
+	sender    Sender
+	templates map[string]*template.Template
+}
+
+// NewTemplateMailer creates a mailer for the given sender. Email templates are
+// parsed here so template errors surface at startup rather than at send time.
+func NewTemplateMailer(sender Sender) (*Mailer, error) {
+	templates, err := parseTemplates()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Mailer{
+		sender:    sender,
+		templates: templates,
+	}, nil
+}
+
+// Send sends an email through the underlying sender, giving up when ctx is
+// done so a stalled provider cannot outlive the request that sends inline
+func (m *Mailer) Send(ctx context.Context, data EmailData) error {
+	return m.sender.Send(ctx, data)
+}
+
+// SendSimple sends a simple text email
+func (m *Mailer) SendSimple(ctx context.Context, to, subject, body string) error {
+	return m.Send(ctx, EmailData{
+		To:      []string{to},
+		Subject: subject,
+		Body:    body,
+		IsHTML:  false,
+	})
 }
 
-// Send sends an email
-func (m *Mailer) Send(data EmailData) error {
+// SendHTML sends an HTML email
+func (m *Mailer) SendHTML(ctx context.Context, to, subject, htmlBody string) error {
+	return m.Send(ctx, EmailData{
+		To:      []string{to},
+		Subject: subject,
+		Body:    htmlBody,
+		IsHTML:  true,
+	})
+}
+
+// buildMessage builds a MIME message from email data
+func buildMessage(from, fromName string, data EmailData) *gomail.Message {
 	msg := gomail.NewMessage()
 
 	// Set sender
-	msg.SetAddressHeader("From", m.from, m.fromName)
+	msg.SetAddressHeader("From", from, fromName)
 
 	// Set recipients
 	msg.SetHeader("To", data.To...)
@@ -73,32 +155,19 @@ func (m *Mailer) Send(data EmailData) error {
 		msg.Attach(attachment)
 	}
 
-	// Send email
-	if err := m.dialer.DialAndSend(msg); err != nil {
-		logger.Errorf("Failed to send email: %v", err)
-		return err
+	// Add in-memory attachments, streamed straight into the encoder
+	for _, attachment := range data.InlineAttachments {
+		content := attachment.Content
+		msg.Attach(attachment.Filename,
+			gomail.SetHeader(map[string][]string{
+				"Content-Type": {fmt.Sprintf("%s; name=%q", attachment.contentType(), attachment.Filename)},
+			}),
+			gomail.SetCopyFunc(func(w io.Writer) error {
+				_, err := w.Write(content)
+				return err
+			}),
+		)
 	}
 
-	logger.Infof("Email sent successfully to: %v", data.To)
-	return nil
-}
-
-// SendSimple sends a simple text email
-func (m *Mailer) SendSimple(to, subject, body string) error {
-	return m.Send(EmailData{
-		To:      []string{to},
-		Subject: subject,
-		Body:    body,
-		IsHTML:  false,
-	})
-}
-
-// SendHTML sends an HTML email
-func (m *Mailer) SendHTML(to, subject, htmlBody string) error {
-	return m.Send(EmailData{
-		To:      []string{to},
-		Subject: subject,
-		Body:    htmlBody,
-		IsHTML:  true,
-	})
+	return msg
 }
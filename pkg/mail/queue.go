@@ -0,0 +1,161 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/your-username/go-clean-architecture/config"
+	"github.com/your-username/go-clean-architecture/pkg/logger"
+)
+
+// Queue errors
+var (
+	ErrQueueFull   = errors.New("mail queue is full")
+	ErrQueueClosed = errors.New("mail queue is closed")
+)
+
+// QueueMailer sends emails asynchronously through a pool of workers
+type QueueMailer struct {
+	sender     Sender
+	queue      chan EmailData
+	maxRetries int
+	backoff    time.Duration
+
+	mu     sync.RWMutex
+	closed bool
+	wg     sync.WaitGroup
+
+	// ctx is cancelled once the shutdown deadline has passed, aborting
+	// sends in progress and retry backoffs
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// NewQueueMailer creates a queue mailer and starts its workers
+func NewQueueMailer(sender Sender, cfg *config.MailQueueConfig) *QueueMailer {
+	workers := cfg.Workers
+	if workers < 1 {
+		workers = 1
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	q := &QueueMailer{
+		sender:     sender,
+		queue:      make(chan EmailData, cfg.Size),
+		maxRetries: cfg.MaxRetries,
+		backoff:    cfg.RetryBackoff,
+		ctx:        ctx,
+		cancel:     cancel,
+	}
+
+	for i := 0; i < workers; i++ {
+		q.wg.Add(1)
+		go q.worker()
+	}
+
+	logger.Infof("Mail queue started with %d workers", workers)
+	return q
+}
+
+// Enqueue adds an email to the queue without blocking
+func (q *QueueMailer) Enqueue(data EmailData) error {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	if q.closed {
+		return ErrQueueClosed
+	}
+
+	select {
+	case q.queue <- data:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// Send implements Sender by queueing the email, so a Mailer can render
+// templates and send them asynchronously. It only fails when the email cannot
+// be queued; delivery failures are logged by the workers.
+func (q *QueueMailer) Send(ctx context.Context, data EmailData) error {
+	return q.Enqueue(data)
+}
+
+// Shutdown stops accepting emails and waits for pending ones to be sent.
+// Emails still queued when ctx is done are dropped.
+func (q *QueueMailer) Shutdown(ctx context.Context) error {
+	q.mu.Lock()
+	if !q.closed {
+		q.closed = true
+		close(q.queue)
+	}
+	q.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		q.cancel()
+		logger.Info("Mail queue flushed")
+		return nil
+	case <-ctx.Done():
+		q.cancel()
+		logger.Warnf("Mail queue shutdown deadline exceeded with %d emails pending", len(q.queue))
+		return ctx.Err()
+	}
+}
+
+// worker drains the queue until it is closed
+func (q *QueueMailer) worker() {
+	defer q.wg.Done()
+
+	for data := range q.queue {
+		if q.ctx.Err() != nil {
+			q.logFailure(data, ErrQueueClosed)
+			continue
+		}
+		q.sendWithRetry(data)
+	}
+}
+
+// sendWithRetry sends an email, retrying with exponential backoff
+func (q *QueueMailer) sendWithRetry(data EmailData) {
+	backoff := q.backoff
+	var err error
+
+	for attempt := 0; attempt <= q.maxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-time.After(backoff):
+				backoff *= 2
+			case <-q.ctx.Done():
+				q.logFailure(data, err)
+				return
+			}
+		}
+
+		if err = q.sender.Send(q.ctx, data); err == nil {
+			return
+		}
+	}
+
+	q.logFailure(data, err)
+}
+
+// logFailure logs an email that could not be delivered
+func (q *QueueMailer) logFailure(data EmailData, err error) {
+	logger.WithFields(logrus.Fields{
+		"to":      data.To,
+		"cc":      data.CC,
+		"bcc":     data.BCC,
+		"subject": data.Subject,
+		"retries": q.maxRetries,
+	}).Errorf("Failed to send queued email: %v", err)
+}
@@ -0,0 +1,171 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/your-username/go-clean-architecture/config"
+	"github.com/your-username/go-clean-architecture/pkg/logger"
+)
+
+// defaultSendGridEndpoint is the SendGrid v3 mail send API
+const defaultSendGridEndpoint = "https://api.sendgrid.com/v3/mail/send"
+
+// SendGridSender sends emails through the SendGrid v3 API
+type SendGridSender struct {
+	apiKey   string
+	endpoint string
+	from     string
+	fromName string
+	client   *http.Client
+}
+
+// NewSendGridSender creates a new SendGrid sender
+func NewSendGridSender(from, fromName string, cfg *config.SendGridConfig) *SendGridSender {
+	endpoint := cfg.Endpoint
+	if endpoint == "" {
+		endpoint = defaultSendGridEndpoint
+	}
+
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	return &SendGridSender{
+		apiKey:   cfg.APIKey,
+		endpoint: endpoint,
+		from:     from,
+		fromName: fromName,
+		client:   &http.Client{Timeout: timeout},
+	}
+}
+
+type sendGridAddress struct {
+	Email string `json:"email"`
+	Name  string `json:"name,omitempty"`
+}
+
+type sendGridPersonalization struct {
+	To  []sendGridAddress `json:"to"`
+	CC  []sendGridAddress `json:"cc,omitempty"`
+	BCC []sendGridAddress `json:"bcc,omitempty"`
+}
+
+type sendGridContent struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type sendGridAttachment struct {
+	Content     string `json:"content"`
+	Filename    string `json:"filename"`
+	Type        string `json:"type,omitempty"`
+	Disposition string `json:"disposition"`
+}
+
+type sendGridMessage struct {
+	Personalizations []sendGridPersonalization `json:"personalizations"`
+	From             sendGridAddress           `json:"from"`
+	Subject          string                    `json:"subject"`
+	Content          []sendGridContent         `json:"content"`
+	Attachments      []sendGridAttachment      `json:"attachments,omitempty"`
+}
+
+// Send sends an email through SendGrid
+func (s *SendGridSender) Send(ctx context.Context, data EmailData) error {
+	payload, err := s.buildPayload(data)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+s.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		logger.Errorf("Failed to send email via SendGrid: %v", err)
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		err := fmt.Errorf("sendgrid responded with status %d: %s", resp.StatusCode, body)
+		logger.Errorf("Failed to send email via SendGrid: %v", err)
+		return err
+	}
+
+	logger.Infof("Email sent successfully to: %v via SendGrid", data.To)
+	return nil
+}
+
+// buildPayload maps email data to a SendGrid request body
+func (s *SendGridSender) buildPayload(data EmailData) ([]byte, error) {
+	contentType := "text/plain"
+	if data.IsHTML {
+		contentType = "text/html"
+	}
+
+	msg := sendGridMessage{
+		Personalizations: []sendGridPersonalization{{
+			To:  toSendGridAddresses(data.To),
+			CC:  toSendGridAddresses(data.CC),
+			BCC: toSendGridAddresses(data.BCC),
+		}},
+		From:    sendGridAddress{Email: s.from, Name: s.fromName},
+		Subject: data.Subject,
+		Content: []sendGridContent{{Type: contentType, Value: data.Body}},
+	}
+
+	for _, path := range data.Attachments {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read attachment %s: %w", path, err)
+		}
+
+		msg.Attachments = append(msg.Attachments, sendGridAttachment{
+			Content:     base64.StdEncoding.EncodeToString(content),
+			Filename:    filepath.Base(path),
+			Type:        mime.TypeByExtension(filepath.Ext(path)),
+			Disposition: "attachment",
+		})
+	}
+
+	for _, attachment := range data.InlineAttachments {
+		msg.Attachments = append(msg.Attachments, sendGridAttachment{
+			Content:     base64.StdEncoding.EncodeToString(attachment.Content),
+			Filename:    attachment.Filename,
+			Type:        attachment.contentType(),
+			Disposition: "attachment",
+		})
+	}
+
+	return json.Marshal(msg)
+}
+
+// toSendGridAddresses converts plain addresses to SendGrid address objects
+func toSendGridAddresses(addresses []string) []sendGridAddress {
+	if len(addresses) == 0 {
+		return nil
+	}
+
+	result := make([]sendGridAddress, 0, len(addresses))
+	for _, address := range addresses {
+		result = append(result, sendGridAddress{Email: address})
+	}
+	return result
+}
@@ -0,0 +1,85 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/ses"
+	"github.com/aws/aws-sdk-go-v2/service/ses/types"
+	"github.com/your-username/go-clean-architecture/config"
+	"github.com/your-username/go-clean-architecture/pkg/logger"
+)
+
+// SESSender sends emails through AWS SES as raw MIME messages
+type SESSender struct {
+	client   *ses.Client
+	from     string
+	fromName string
+	timeout  time.Duration
+}
+
+// NewSESSender creates a new SES sender. Static credentials are used when
+// configured, otherwise the default AWS credential chain applies.
+func NewSESSender(from, fromName string, cfg *config.SESConfig) (*SESSender, error) {
+	opts := []func(*awsconfig.LoadOptions) error{
+		awsconfig.WithRegion(cfg.Region),
+	}
+	if cfg.AccessKeyID != "" {
+		opts = append(opts, awsconfig.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider(cfg.AccessKeyID, cfg.SecretAccessKey, ""),
+		))
+	}
+
+	awsCfg, err := awsconfig.LoadDefaultConfig(context.Background(), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	return &SESSender{
+		client:   ses.NewFromConfig(awsCfg),
+		from:     from,
+		fromName: fromName,
+		timeout:  timeout,
+	}, nil
+}
+
+// Send sends an email through SES
+func (s *SESSender) Send(ctx context.Context, data EmailData) error {
+	msg := buildMessage(s.from, s.fromName, data)
+
+	var raw bytes.Buffer
+	if _, err := msg.WriteTo(&raw); err != nil {
+		return err
+	}
+
+	// Bcc is not written to the MIME headers, so list every recipient explicitly
+	destinations := make([]string, 0, len(data.To)+len(data.CC)+len(data.BCC))
+	destinations = append(destinations, data.To...)
+	destinations = append(destinations, data.CC...)
+	destinations = append(destinations, data.BCC...)
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	_, err := s.client.SendRawEmail(ctx, &ses.SendRawEmailInput{
+		Source:       aws.String(s.from),
+		Destinations: destinations,
+		RawMessage:   &types.RawMessage{Data: raw.Bytes()},
+	})
+	if err != nil {
+		logger.Errorf("Failed to send email via SES: %v", err)
+		return err
+	}
+
+	logger.Infof("Email sent successfully to: %v via SES", data.To)
+	return nil
+}
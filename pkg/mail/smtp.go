@@ -0,0 +1,276 @@
+package mail
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/smtp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/your-username/go-clean-architecture/config"
+	"github.com/your-username/go-clean-architecture/pkg/logger"
+	"gopkg.in/gomail.v2"
+)
+
+// ErrAllProvidersFailed is returned when every configured SMTP provider failed to send
+var ErrAllProvidersFailed = errors.New("all smtp providers failed")
+
+// messageSender delivers gomail messages, implemented by *smtpDialer
+type messageSender interface {
+	Send(ctx context.Context, msg *gomail.Message) error
+}
+
+// provider holds a single SMTP provider used by the sender
+type provider struct {
+	host    string
+	sender  messageSender
+	timeout time.Duration
+}
+
+// SMTPSender sends emails over SMTP using gomail
+type SMTPSender struct {
+	providers []provider
+	from      string
+	fromName  string
+}
+
+// NewSMTPSender creates a new SMTP sender. Providers are tried in order, so
+// every provider after the first acts as a fallback.
+func NewSMTPSender(from, fromName string, providers ...config.SMTPConfig) *SMTPSender {
+	s := &SMTPSender{
+		providers: make([]provider, 0, len(providers)),
+		from:      from,
+		fromName:  fromName,
+	}
+
+	for _, c := range providers {
+		dialer := &smtpDialer{
+			host:     c.Host,
+			port:     c.Port,
+			username: c.Username,
+			password: c.Password,
+		}
+		minVersion, ok := c.TLSVersion()
+		if !ok {
+			minVersion = tls.VersionTLS12
+		}
+		dialer.tlsConfig = &tls.Config{
+			ServerName:         c.Host,
+			InsecureSkipVerify: c.TLSSkipVerify,
+			MinVersion:         minVersion,
+		}
+		// Auto picks implicit TLS for port 465. Without it, STARTTLS is used
+		// whenever the server offers it.
+		switch c.TLSMode {
+		case config.SMTPTLSImplicit:
+			dialer.implicitTLS = true
+		case config.SMTPTLSStartTLS:
+			dialer.implicitTLS = false
+		default:
+			dialer.implicitTLS = c.Port == 465
+		}
+		if c.TLSSkipVerify {
+			logger.Warnf("TLS certificate verification is disabled for SMTP provider %s, do not use this in production", c.Host)
+		}
+
+		s.providers = append(s.providers, provider{
+			host:    c.Host,
+			sender:  dialer,
+			timeout: c.Timeout,
+		})
+	}
+
+	return s
+}
+
+// Send sends an email, failing over to the next provider on error. Once ctx
+// is done no further provider is tried.
+func (s *SMTPSender) Send(ctx context.Context, data EmailData) error {
+	msg := buildMessage(s.from, s.fromName, data)
+
+	// Send email, trying each provider in order
+	var errs []error
+	for i, p := range s.providers {
+		err := p.send(ctx, msg)
+		if err == nil {
+			logger.Infof("Email sent successfully to: %v via %s", data.To, p.host)
+			return nil
+		}
+
+		errs = append(errs, fmt.Errorf("%s: %w", p.host, err))
+		if ctx.Err() != nil {
+			break
+		}
+		if i < len(s.providers)-1 {
+			logger.Warnf("Failed to send email via %s, failing over to %s: %v", p.host, s.providers[i+1].host, err)
+		}
+	}
+
+	err := fmt.Errorf("%w: %w", ErrAllProvidersFailed, errors.Join(errs...))
+	logger.Errorf("Failed to send email: %v", err)
+	return err
+}
+
+// send delivers the message through the provider, within its timeout
+func (p provider) send(ctx context.Context, msg *gomail.Message) error {
+	if p.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.timeout)
+		defer cancel()
+	}
+	return p.sender.Send(ctx, msg)
+}
+
+// smtpDialDefaultTimeout bounds the dial when ctx has no deadline
+const smtpDialDefaultTimeout = 10 * time.Second
+
+// smtpDialer delivers messages to an SMTP server, one connection per message
+type smtpDialer struct {
+	host     string
+	port     int
+	username string
+	password string
+	// implicitTLS dials over TLS; otherwise STARTTLS is used when offered
+	implicitTLS bool
+	tlsConfig   *tls.Config
+}
+
+// Send dials the server and delivers msg. The connection expires when ctx is
+// done, so a send that is given up on stops there rather than delivering the
+// message after the caller has failed over to another provider.
+func (d *smtpDialer) Send(ctx context.Context, msg *gomail.Message) error {
+	dialer := net.Dialer{Timeout: smtpDialDefaultTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(d.host, strconv.Itoa(d.port)))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// Expiring the connection once ctx is done fails any read or write in
+	// progress, so ctx.Err() is set by the time they return
+	stop := context.AfterFunc(ctx, func() {
+		_ = conn.SetDeadline(time.Now())
+	})
+	defer stop()
+
+	if err := d.deliver(conn, msg); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return fmt.Errorf("%w: %v", ctxErr, err)
+		}
+		return err
+	}
+	return nil
+}
+
+// deliver runs the SMTP conversation on conn
+func (d *smtpDialer) deliver(conn net.Conn, msg *gomail.Message) error {
+	if d.implicitTLS {
+		conn = tls.Client(conn, d.tlsConfig)
+	}
+
+	c, err := smtp.NewClient(conn, d.host)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	if !d.implicitTLS {
+		if ok, _ := c.Extension("STARTTLS"); ok {
+			if err := c.StartTLS(d.tlsConfig); err != nil {
+				return err
+			}
+		}
+	}
+
+	if auth := d.auth(c); auth != nil {
+		if err := c.Auth(auth); err != nil {
+			return err
+		}
+	}
+
+	err = gomail.Send(gomail.SendFunc(func(from string, to []string, body io.WriterTo) error {
+		if err := c.Mail(from); err != nil {
+			return err
+		}
+		for _, addr := range to {
+			if err := c.Rcpt(addr); err != nil {
+				return err
+			}
+		}
+
+		w, err := c.Data()
+		if err != nil {
+			return err
+		}
+		if _, err := body.WriteTo(w); err != nil {
+			_ = w.Close()
+			return err
+		}
+		return w.Close()
+	}), msg)
+	if err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+// auth picks the strongest mechanism the server offers, as gomail does, or
+// nil when there are no credentials or the server takes no AUTH
+func (d *smtpDialer) auth(c *smtp.Client) smtp.Auth {
+	if d.username == "" {
+		return nil
+	}
+	ok, mechanisms := c.Extension("AUTH")
+	if !ok {
+		return nil
+	}
+
+	switch {
+	case strings.Contains(mechanisms, "CRAM-MD5"):
+		return smtp.CRAMMD5Auth(d.username, d.password)
+	case strings.Contains(mechanisms, "LOGIN") && !strings.Contains(mechanisms, "PLAIN"):
+		return &loginAuth{username: d.username, password: d.password, host: d.host}
+	default:
+		return smtp.PlainAuth("", d.username, d.password, d.host)
+	}
+}
+
+// loginAuth implements the LOGIN mechanism, which net/smtp lacks
+type loginAuth struct {
+	username string
+	password string
+	host     string
+}
+
+// Start begins LOGIN, refusing to send credentials in the clear unless the
+// server asked for LOGIN, as smtp.PlainAuth does
+func (a *loginAuth) Start(server *smtp.ServerInfo) (string, []byte, error) {
+	if !server.TLS && !slices.Contains(server.Auth, "LOGIN") {
+		return "", nil, errors.New("unencrypted connection")
+	}
+	if server.Name != a.host {
+		return "", nil, errors.New("wrong host name")
+	}
+	return "LOGIN", nil, nil
+}
+
+// Next answers the server's username and password prompts
+func (a *loginAuth) Next(fromServer []byte, more bool) ([]byte, error) {
+	if !more {
+		return nil, nil
+	}
+	switch string(fromServer) {
+	case "Username:":
+		return []byte(a.username), nil
+	case "Password:":
+		return []byte(a.password), nil
+	default:
+		return nil, fmt.Errorf("unexpected server challenge: %s", fromServer)
+	}
+}
@@ -0,0 +1,235 @@
+package mail
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/your-username/go-clean-architecture/config"
+	"github.com/your-username/go-clean-architecture/pkg/logger"
+	"gopkg.in/gomail.v2"
+)
+
+func TestMain(m *testing.M) {
+	logger.InitLogger(false)
+	os.Exit(m.Run())
+}
+
+// fakeSender fails with err, counting its calls
+type fakeSender struct {
+	err   error
+	calls int
+}
+
+func (f *fakeSender) Send(context.Context, *gomail.Message) error {
+	f.calls++
+	return f.err
+}
+
+func testEmail() EmailData {
+	return EmailData{To: []string{"jane@example.com"}, Subject: "Hello", Body: "Hi Jane"}
+}
+
+func TestSMTPSenderFailsOverToFallback(t *testing.T) {
+	primary := &fakeSender{err: errors.New("connection refused")}
+	fallback := &fakeSender{}
+	s := &SMTPSender{
+		providers: []provider{
+			{host: "primary", sender: primary},
+			{host: "fallback", sender: fallback},
+		},
+		from: "noreply@example.com",
+	}
+
+	if err := s.Send(context.Background(), testEmail()); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if primary.calls != 1 || fallback.calls != 1 {
+		t.Errorf("calls = primary %d, fallback %d; want 1 each", primary.calls, fallback.calls)
+	}
+}
+
+func TestSMTPSenderReportsAllProvidersFailed(t *testing.T) {
+	s := &SMTPSender{
+		providers: []provider{
+			{host: "primary", sender: &fakeSender{err: errors.New("refused")}},
+			{host: "fallback", sender: &fakeSender{err: errors.New("refused")}},
+		},
+		from: "noreply@example.com",
+	}
+
+	err := s.Send(context.Background(), testEmail())
+	if !errors.Is(err, ErrAllProvidersFailed) {
+		t.Fatalf("Send() error = %v, want ErrAllProvidersFailed", err)
+	}
+}
+
+// listen starts a TCP listener on a random local port, serving each
+// connection with serve
+func listen(t *testing.T, serve func(net.Conn)) (host string, port int) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go serve(conn)
+		}
+	}()
+
+	addr := ln.Addr().(*net.TCPAddr)
+	return addr.IP.String(), addr.Port
+}
+
+// smtpServer answers a plain SMTP conversation, sending the received message
+// data on received
+func smtpServer(received chan<- string) func(net.Conn) {
+	return func(conn net.Conn) {
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		reply := func(line string) { _, _ = io.WriteString(conn, line+"\r\n") }
+
+		reply("220 localhost ESMTP")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			switch cmd := strings.ToUpper(strings.TrimSpace(line)); {
+			case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+				reply("250 localhost")
+			case strings.HasPrefix(cmd, "MAIL"), strings.HasPrefix(cmd, "RCPT"):
+				reply("250 OK")
+			case cmd == "DATA":
+				reply("354 Go ahead")
+				var data strings.Builder
+				for {
+					line, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if line == ".\r\n" {
+						break
+					}
+					data.WriteString(line)
+				}
+				received <- data.String()
+				reply("250 Queued")
+			case cmd == "QUIT":
+				reply("221 Bye")
+				return
+			default:
+				reply("502 Unknown command")
+			}
+		}
+	}
+}
+
+// silentServer accepts connections and never answers, reporting when the
+// client closes its connection
+func silentServer(closed chan<- struct{}) func(net.Conn) {
+	return func(conn net.Conn) {
+		defer conn.Close()
+		_, _ = io.Copy(io.Discard, conn)
+		closed <- struct{}{}
+	}
+}
+
+func smtpConfig(host string, port int, timeout time.Duration) config.SMTPConfig {
+	return config.SMTPConfig{Host: host, Port: port, Timeout: timeout, TLSMode: config.SMTPTLSStartTLS}
+}
+
+func TestSMTPDialerDeliversMessage(t *testing.T) {
+	received := make(chan string, 1)
+	host, port := listen(t, smtpServer(received))
+
+	s := NewSMTPSender("noreply@example.com", "App", smtpConfig(host, port, time.Second))
+	if err := s.Send(context.Background(), testEmail()); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	select {
+	case data := <-received:
+		if !strings.Contains(data, "Subject: Hello") || !strings.Contains(data, "Hi Jane") {
+			t.Errorf("received message = %q, want the subject and body", data)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("server received no message")
+	}
+}
+
+func TestSMTPDialerTimeoutClosesConnection(t *testing.T) {
+	closed := make(chan struct{}, 1)
+	host, port := listen(t, silentServer(closed))
+
+	d := NewSMTPSender("noreply@example.com", "App", smtpConfig(host, port, 100*time.Millisecond)).providers[0]
+	start := time.Now()
+	err := d.send(context.Background(), buildMessage("noreply@example.com", "App", testEmail()))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("send() error = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("send() took %s, want it bounded by the timeout", elapsed)
+	}
+
+	// The connection is gone, so the timed-out send cannot deliver later
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("connection still open after the timeout")
+	}
+}
+
+func TestSMTPSenderFailsOverAfterTimeout(t *testing.T) {
+	closed := make(chan struct{}, 1)
+	silentHost, silentPort := listen(t, silentServer(closed))
+	received := make(chan string, 1)
+	host, port := listen(t, smtpServer(received))
+
+	s := NewSMTPSender("noreply@example.com", "App",
+		smtpConfig(silentHost, silentPort, 100*time.Millisecond),
+		smtpConfig(host, port, time.Second),
+	)
+	if err := s.Send(context.Background(), testEmail()); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	select {
+	case <-received:
+	case <-time.After(time.Second):
+		t.Fatal("fallback received no message")
+	}
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("primary connection still open after failing over")
+	}
+}
+
+func TestSMTPDialerImplicitTLSByPort(t *testing.T) {
+	cfg := smtpConfig("smtp.example.com", 465, time.Second)
+	cfg.TLSMode = config.SMTPTLSAuto
+	d := NewSMTPSender("noreply@example.com", "App", cfg).providers[0].sender.(*smtpDialer)
+	if !d.implicitTLS {
+		t.Error("auto TLS mode on port 465 did not pick implicit TLS")
+	}
+
+	cfg.Port = 587
+	d = NewSMTPSender("noreply@example.com", "App", cfg).providers[0].sender.(*smtpDialer)
+	if d.implicitTLS {
+		t.Errorf("auto TLS mode on port %d picked implicit TLS", cfg.Port)
+	}
+}
@@ -0,0 +1,76 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+//go:embed templates/*.html
+var templateFS embed.FS
+
+// layoutTemplate is the base layout every email template renders into
+const layoutTemplate = "layout.html"
+
+// Template names shipped with the mailer
+const (
+	TemplateWelcome       = "welcome"
+	TemplatePasswordReset = "password_reset"
+)
+
+// parseTemplates parses every embedded template against the base layout
+func parseTemplates() (map[string]*template.Template, error) {
+	layout, err := template.ParseFS(templateFS, path.Join("templates", layoutTemplate))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse email layout: %w", err)
+	}
+
+	files, err := fs.Glob(templateFS, "templates/*.html")
+	if err != nil {
+		return nil, err
+	}
+
+	templates := make(map[string]*template.Template)
+	for _, file := range files {
+		name := path.Base(file)
+		if name == layoutTemplate {
+			continue
+		}
+
+		tmpl, err := template.Must(layout.Clone()).ParseFS(templateFS, file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse email template %s: %w", name, err)
+		}
+		templates[strings.TrimSuffix(name, ".html")] = tmpl
+	}
+
+	return templates, nil
+}
+
+// renderTemplate renders a named template with the given data
+func (m *Mailer) renderTemplate(name string, data interface{}) (string, error) {
+	tmpl, ok := m.templates[name]
+	if !ok {
+		return "", fmt.Errorf("email template %q not found", name)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&buf, "layout", data); err != nil {
+		return "", fmt.Errorf("failed to render email template %s: %w", name, err)
+	}
+	return buf.String(), nil
+}
+
+// SendTemplate renders an HTML template and sends it
+func (m *Mailer) SendTemplate(ctx context.Context, to, subject, templateName string, data interface{}) error {
+	body, err := m.renderTemplate(templateName, data)
+	if err != nil {
+		return err
+	}
+	return m.SendHTML(ctx, to, subject, body)
+}
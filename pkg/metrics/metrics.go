@@ -0,0 +1,93 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// HTTP metrics
+var (
+	HTTPRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "Total number of HTTP requests.",
+	}, []string{"method", "route", "status"})
+
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "HTTP request latency in seconds.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	HTTPRequestsInFlight = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "http_requests_in_flight",
+		Help: "Number of HTTP requests currently being served.",
+	}, []string{"method", "route"})
+
+	PanicsRecoveredTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "http_panics_recovered_total",
+		Help: "Total number of panics recovered by the recovery middleware.",
+	})
+)
+
+// Database metrics, labeled by GORM operation (create, query, update, delete,
+// row or raw) and table. The table is empty when table labels are disabled
+// or the statement has no model, such as most raw queries.
+var (
+	DBQueriesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "db_queries_total",
+		Help: "Total number of database queries.",
+	}, []string{"operation", "table"})
+
+	DBQueryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "db_query_duration_seconds",
+		Help:    "Database query latency in seconds.",
+		Buckets: []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"operation", "table"})
+
+	DBQueryErrorsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "db_query_errors_total",
+		Help: "Total number of failed database queries, not counting record not found.",
+	}, []string{"operation", "table"})
+)
+
+// Database connection pool metrics, sampled periodically from the primary
+// pool. The wait count and duration are totals since startup.
+var (
+	DBConnectionsOpen = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "db_connections_open",
+		Help: "Number of open database connections, in use or idle.",
+	})
+
+	DBConnectionsInUse = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "db_connections_in_use",
+		Help: "Number of database connections currently in use.",
+	})
+
+	DBConnectionsIdle = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "db_connections_idle",
+		Help: "Number of idle database connections.",
+	})
+
+	DBConnectionsMaxOpen = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "db_connections_max_open",
+		Help: "Maximum number of open database connections, 0 for unlimited.",
+	})
+
+	DBConnectionsWaitCount = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "db_connections_wait_count",
+		Help: "Total number of times a query waited for a free database connection.",
+	})
+
+	DBConnectionsWaitDuration = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "db_connections_wait_duration_seconds",
+		Help: "Total time spent waiting for a free database connection.",
+	})
+)
+
+// Handler returns the HTTP handler exposing metrics in Prometheus format
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
@@ -0,0 +1,99 @@
+// Package pagination binds page and limit query parameters for list endpoints
+package pagination
+
+import (
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-username/go-clean-architecture/config"
+	"github.com/your-username/go-clean-architecture/pkg/constants"
+)
+
+var (
+	limits = config.PaginationConfig{
+		DefaultPage:  constants.DefaultPage,
+		DefaultLimit: constants.DefaultLimit,
+		MaxLimit:     constants.MaxLimit,
+	}
+	limitsMu sync.RWMutex
+)
+
+// SetLimits sets the page defaults and limit cap of list endpoints. Values
+// that are not positive fall back to the constants defaults.
+func SetLimits(cfg config.PaginationConfig) {
+	if cfg.DefaultPage < 1 {
+		cfg.DefaultPage = constants.DefaultPage
+	}
+	if cfg.MaxLimit < 1 {
+		cfg.MaxLimit = constants.MaxLimit
+	}
+	if cfg.DefaultLimit < 1 {
+		cfg.DefaultLimit = min(constants.DefaultLimit, cfg.MaxLimit)
+	}
+
+	limitsMu.Lock()
+	defer limitsMu.Unlock()
+	limits = cfg
+}
+
+// Limits returns the page defaults and limit cap of list endpoints
+func Limits() config.PaginationConfig {
+	limitsMu.RLock()
+	defer limitsMu.RUnlock()
+	return limits
+}
+
+// Normalize replaces missing page and limit values with the defaults and
+// caps limit at the maximum
+func Normalize(page, limit int) (int, int) {
+	l := Limits()
+	if page < 1 {
+		page = l.DefaultPage
+	}
+	if limit < 1 {
+		limit = l.DefaultLimit
+	}
+	if limit > l.MaxLimit {
+		limit = l.MaxLimit
+	}
+	return page, limit
+}
+
+// TotalMode is how the total of a list is computed
+type TotalMode string
+
+// Total modes
+const (
+	// TotalExact counts every matching record
+	TotalExact TotalMode = "exact"
+	// TotalEstimated uses the database's estimate, which is fast on large
+	// tables but approximate
+	TotalEstimated TotalMode = "estimated"
+	// TotalOmitted skips counting, for clients that only page forward
+	TotalOmitted TotalMode = "omitted"
+)
+
+// BindTotal reads the count query parameter: true, the default, for an exact
+// total, estimate for an estimated one, or false to omit it. Unknown values
+// fall back to an exact total.
+func BindTotal(c *gin.Context) TotalMode {
+	switch c.Query("count") {
+	case "false":
+		return TotalOmitted
+	case "estimate":
+		return TotalEstimated
+	default:
+		return TotalExact
+	}
+}
+
+// Bind reads the page and limit query parameters. Missing or invalid values
+// fall back to the defaults and limit is capped at the maximum.
+func Bind(c *gin.Context) (page, limit int) {
+	var query struct {
+		Page  int `form:"page"`
+		Limit int `form:"limit"`
+	}
+	_ = c.ShouldBindQuery(&query)
+	return Normalize(query.Page, query.Limit)
+}
@@ -0,0 +1,82 @@
+// Package permission resolves what a role may do and which feature flags it
+// can use
+package permission
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/your-username/go-clean-architecture/config"
+	"github.com/your-username/go-clean-architecture/pkg/constants"
+)
+
+// Permissions
+const (
+	UsersRead     = "users:read"
+	UsersUpdate   = "users:update"
+	UsersDelete   = "users:delete"
+	UsersRestore  = "users:restore"
+	UsersPurge    = "users:purge"
+	UsersExport   = "users:export"
+	AuditLogsRead = "audit_logs:read"
+	ProfileRead   = "profile:read"
+	ProfileAvatar = "profile:avatar"
+)
+
+// rolePermissions is the permission registry
+var rolePermissions = map[string][]string{
+	constants.RoleUser: {
+		ProfileRead,
+		ProfileAvatar,
+		UsersRead,
+		UsersUpdate,
+		UsersDelete,
+	},
+	constants.RoleAdmin: {
+		ProfileRead,
+		ProfileAvatar,
+		UsersRead,
+		UsersUpdate,
+		UsersDelete,
+		UsersRestore,
+		UsersPurge,
+		UsersExport,
+		AuditLogsRead,
+	},
+}
+
+var (
+	featureFlags   []config.FeatureFlag
+	featureFlagsMu sync.RWMutex
+)
+
+// SetFeatureFlags sets the enabled feature flags
+func SetFeatureFlags(flags []config.FeatureFlag) {
+	featureFlagsMu.Lock()
+	defer featureFlagsMu.Unlock()
+	featureFlags = flags
+}
+
+// ForRole returns the permissions granted to role, none for unknown roles
+func ForRole(role string) []string {
+	return append([]string{}, rolePermissions[role]...)
+}
+
+// Has reports whether role is granted permission
+func Has(role, permission string) bool {
+	return slices.Contains(rolePermissions[role], permission)
+}
+
+// FeaturesForRole returns the names of the feature flags available to role
+func FeaturesForRole(role string) []string {
+	featureFlagsMu.RLock()
+	defer featureFlagsMu.RUnlock()
+
+	features := make([]string, 0, len(featureFlags))
+	for _, flag := range featureFlags {
+		if len(flag.Roles) == 0 || slices.Contains(flag.Roles, role) {
+			features = append(features, flag.Name)
+		}
+	}
+	return features
+}
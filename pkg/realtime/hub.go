@@ -0,0 +1,103 @@
+package realtime
+
+import (
+	"context"
+	"sync"
+
+	"github.com/your-username/go-clean-architecture/pkg/logger"
+)
+
+// Hub is an in-memory Broker delivering events to streams on this instance
+type Hub struct {
+	mu          sync.RWMutex
+	subscribers map[uint]map[chan Event]struct{}
+	closed      bool
+}
+
+// NewHub creates a new in-memory hub
+func NewHub() *Hub {
+	return &Hub{subscribers: make(map[uint]map[chan Event]struct{})}
+}
+
+// Publish implements Publisher
+func (h *Hub) Publish(_ context.Context, userID uint, event Event) error {
+	h.deliver(userID, event)
+	return nil
+}
+
+// Subscribe implements Broker
+func (h *Hub) Subscribe(userID uint) (<-chan Event, func()) {
+	ch := make(chan Event, subscriberBuffer)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		close(ch)
+		return ch, func() {}
+	}
+
+	if h.subscribers[userID] == nil {
+		h.subscribers[userID] = make(map[chan Event]struct{})
+	}
+	h.subscribers[userID][ch] = struct{}{}
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() { h.unsubscribe(userID, ch) })
+	}
+}
+
+// Close implements Broker
+func (h *Hub) Close() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return nil
+	}
+	h.closed = true
+
+	for _, channels := range h.subscribers {
+		for ch := range channels {
+			close(ch)
+		}
+	}
+	h.subscribers = nil
+	return nil
+}
+
+// deliver sends event to each of the user's subscribers without blocking.
+// Events are dropped with a warning for subscribers that fell behind.
+func (h *Hub) deliver(userID uint, event Event) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for ch := range h.subscribers[userID] {
+		select {
+		case ch <- event:
+		default:
+			logger.Warnf("Dropped %s event for user %d: subscriber is not keeping up", event.Type, userID)
+		}
+	}
+}
+
+// unsubscribe removes and closes a subscriber channel
+func (h *Hub) unsubscribe(userID uint, ch chan Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	channels, ok := h.subscribers[userID]
+	if !ok {
+		return
+	}
+	if _, ok := channels[ch]; !ok {
+		return
+	}
+
+	delete(channels, ch)
+	close(ch)
+	if len(channels) == 0 {
+		delete(h.subscribers, userID)
+	}
+}
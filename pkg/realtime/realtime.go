@@ -0,0 +1,56 @@
+// Package realtime delivers server-sent events to connected users
+package realtime
+
+import (
+	"context"
+
+	"github.com/your-username/go-clean-architecture/pkg/database"
+	"github.com/your-username/go-clean-architecture/pkg/logger"
+)
+
+// Event types. Adding one only needs a constant here and a Publish call,
+// usually from a subscriber of the matching use case event.
+const (
+	// EventUserUpdated is sent to a user when their profile changes
+	EventUserUpdated = "user.updated"
+	// EventServerShutdown is the last event of a stream ended by the server
+	// shutting down, telling the client to reconnect
+	EventServerShutdown = "server.shutdown"
+)
+
+// subscriberBuffer is the number of events a slow subscriber may fall behind
+// before events for it are dropped
+const subscriberBuffer = 16
+
+// Event is a message delivered to a user's open streams
+type Event struct {
+	Type string      `json:"type"`
+	Data interface{} `json:"data"`
+}
+
+// Publisher sends events to users
+type Publisher interface {
+	// Publish delivers event to every stream the user has open
+	Publish(ctx context.Context, userID uint, event Event) error
+}
+
+// Broker routes events to the streams of the user they are published for
+type Broker interface {
+	Publisher
+	// Subscribe returns a channel receiving the user's events and a function
+	// releasing it. The channel is closed when the broker closes.
+	Subscribe(userID uint) (<-chan Event, func())
+	// Close closes every subscription, ending open streams
+	Close() error
+}
+
+// NewBroker creates a broker that fans out through Redis pub/sub so events
+// reach streams on every replica, or an in-memory hub when Redis is
+// unavailable
+func NewBroker(client *database.RedisClient) Broker {
+	if client == nil {
+		logger.Warn("Redis unavailable, events only reach streams on this instance")
+		return NewHub()
+	}
+	return NewRedisBroker(client)
+}
@@ -0,0 +1,90 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/your-username/go-clean-architecture/pkg/database"
+	"github.com/your-username/go-clean-architecture/pkg/logger"
+)
+
+// redisChannel is the pub/sub channel events are published on
+const redisChannel = "realtime:events"
+
+// redisMessage is an event as published on the Redis channel
+type redisMessage struct {
+	UserID uint            `json:"user_id"`
+	Type   string          `json:"type"`
+	Data   json.RawMessage `json:"data"`
+}
+
+// RedisBroker publishes events through Redis pub/sub. Every instance
+// listens on the channel and hands events to its local hub, so a stream
+// receives events published on any replica.
+type RedisBroker struct {
+	client *database.RedisClient
+	hub    *Hub
+	pubsub *redis.PubSub
+	done   chan struct{}
+	once   sync.Once
+}
+
+// NewRedisBroker creates a broker backed by Redis pub/sub and starts
+// listening for events
+func NewRedisBroker(client *database.RedisClient) *RedisBroker {
+	b := &RedisBroker{
+		client: client,
+		hub:    NewHub(),
+		pubsub: client.Client.Subscribe(context.Background(), redisChannel),
+		done:   make(chan struct{}),
+	}
+	go b.listen()
+	return b
+}
+
+// Publish implements Publisher
+func (b *RedisBroker) Publish(ctx context.Context, userID uint, event Event) error {
+	data, err := json.Marshal(event.Data)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(redisMessage{UserID: userID, Type: event.Type, Data: data})
+	if err != nil {
+		return err
+	}
+	return b.client.Publish(ctx, redisChannel, payload)
+}
+
+// Subscribe implements Broker
+func (b *RedisBroker) Subscribe(userID uint) (<-chan Event, func()) {
+	return b.hub.Subscribe(userID)
+}
+
+// Close implements Broker
+func (b *RedisBroker) Close() error {
+	var err error
+	b.once.Do(func() {
+		err = b.pubsub.Close()
+		<-b.done
+		_ = b.hub.Close()
+	})
+	return err
+}
+
+// listen hands events from the Redis channel to the local hub until the
+// subscription is closed. go-redis reconnects the subscription on its own.
+func (b *RedisBroker) listen() {
+	defer close(b.done)
+
+	for msg := range b.pubsub.Channel() {
+		var message redisMessage
+		if err := json.Unmarshal([]byte(msg.Payload), &message); err != nil {
+			logger.Warnf("Ignoring malformed realtime event: %v", err)
+			continue
+		}
+		b.hub.deliver(message.UserID, Event{Type: message.Type, Data: message.Data})
+	}
+}
@@ -0,0 +1,58 @@
+package response
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-username/go-clean-architecture/pkg/i18n"
+)
+
+// SuccessWithETag sends a success response with a strong ETag computed from
+// the serialized body. If the request's If-None-Match matches, it sends
+// 304 Not Modified with no body instead.
+func SuccessWithETag(c *gin.Context, message i18n.Key, data interface{}) {
+	body, err := json.Marshal(Response{
+		Success: true,
+		Message: i18n.T(i18n.FromContext(c), message),
+		Data:    data,
+	})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	c.Header("ETag", etag)
+	// The message, and so the tag, depends on the negotiated locale
+	c.Writer.Header().Add("Vary", "Accept-Language")
+	// Clients may cache but must revalidate, the data is per user
+	c.Header("Cache-Control", "private, no-cache")
+
+	if etagMatches(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json; charset=utf-8", body)
+}
+
+// etagMatches reports whether an If-None-Match header matches etag, using
+// the weak comparison RFC 9110 requires for If-None-Match
+func etagMatches(header, etag string) bool {
+	if header == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
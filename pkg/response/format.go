@@ -0,0 +1,157 @@
+package response
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-username/go-clean-architecture/config"
+	"github.com/your-username/go-clean-architecture/pkg/apperrors"
+)
+
+// JSONAPIMediaType is the media type of JSON:API documents. Clients sending
+// it in Accept get JSON:API errors whatever the configured format.
+const JSONAPIMediaType = "application/vnd.api+json"
+
+// ErrorFormatter builds error response bodies
+type ErrorFormatter interface {
+	// ContentType is the response media type, empty for gin's JSON default
+	ContentType() string
+	// Error builds the body of an error with an optional code and details
+	Error(statusCode int, code, message string, details interface{}) interface{}
+	// Validation builds the body of a validation error with its message and
+	// the errors keyed by field
+	Validation(message string, errors map[string]string) interface{}
+}
+
+var (
+	errorFormatter   ErrorFormatter = EnvelopeFormatter{}
+	errorFormatterMu sync.RWMutex
+)
+
+// SetErrorFormat sets the error format used when the client does not ask for
+// one: config.ErrorFormatEnvelope or config.ErrorFormatJSONAPI. Unknown
+// formats fall back to the envelope.
+func SetErrorFormat(format string) {
+	var formatter ErrorFormatter = EnvelopeFormatter{}
+	if format == config.ErrorFormatJSONAPI {
+		formatter = JSONAPIFormatter{}
+	}
+
+	errorFormatterMu.Lock()
+	defer errorFormatterMu.Unlock()
+	errorFormatter = formatter
+}
+
+// errorFormatterFor returns the formatter for the request, honouring an
+// Accept header that asks for JSON:API
+func errorFormatterFor(c *gin.Context) ErrorFormatter {
+	if strings.Contains(c.GetHeader("Accept"), JSONAPIMediaType) {
+		return JSONAPIFormatter{}
+	}
+
+	errorFormatterMu.RLock()
+	defer errorFormatterMu.RUnlock()
+	return errorFormatter
+}
+
+// writeError sends a body built by formatter
+func writeError(c *gin.Context, formatter ErrorFormatter, statusCode int, body interface{}) {
+	if contentType := formatter.ContentType(); contentType != "" {
+		c.Header("Content-Type", contentType)
+	}
+	c.JSON(statusCode, body)
+}
+
+// EnvelopeFormatter builds the standard Response envelope
+type EnvelopeFormatter struct{}
+
+// ContentType implements ErrorFormatter
+func (EnvelopeFormatter) ContentType() string {
+	return ""
+}
+
+// Error implements ErrorFormatter
+func (EnvelopeFormatter) Error(_ int, code, message string, details interface{}) interface{} {
+	return Response{
+		Success: false,
+		Code:    code,
+		Message: message,
+		Error:   details,
+	}
+}
+
+// Validation implements ErrorFormatter
+func (EnvelopeFormatter) Validation(message string, errors map[string]string) interface{} {
+	return Response{
+		Success: false,
+		Code:    apperrors.SlugValidation,
+		Message: message,
+		Error:   errors,
+	}
+}
+
+// JSONAPIDocument is a JSON:API error document
+type JSONAPIDocument struct {
+	Errors []JSONAPIError `json:"errors"`
+}
+
+// JSONAPIError is a JSON:API error object
+type JSONAPIError struct {
+	Status string         `json:"status"`
+	Code   string         `json:"code,omitempty"`
+	Title  string         `json:"title"`
+	Detail string         `json:"detail,omitempty"`
+	Source *JSONAPISource `json:"source,omitempty"`
+	Meta   interface{}    `json:"meta,omitempty"`
+}
+
+// JSONAPISource points at the part of the request an error is about
+type JSONAPISource struct {
+	Pointer string `json:"pointer"`
+}
+
+// JSONAPIFormatter builds JSON:API error documents
+type JSONAPIFormatter struct{}
+
+// ContentType implements ErrorFormatter
+func (JSONAPIFormatter) ContentType() string {
+	return JSONAPIMediaType
+}
+
+// Error implements ErrorFormatter. The title is the status text, so it is
+// the same for every occurrence, and the message becomes the detail.
+func (JSONAPIFormatter) Error(statusCode int, code, message string, details interface{}) interface{} {
+	return JSONAPIDocument{Errors: []JSONAPIError{{
+		Status: strconv.Itoa(statusCode),
+		Code:   code,
+		Title:  http.StatusText(statusCode),
+		Detail: message,
+		Meta:   details,
+	}}}
+}
+
+// Validation implements ErrorFormatter with one error object per field,
+// ordered by field name
+func (JSONAPIFormatter) Validation(message string, errors map[string]string) interface{} {
+	fields := make([]string, 0, len(errors))
+	for field := range errors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	objects := make([]JSONAPIError, 0, len(fields))
+	for _, field := range fields {
+		objects = append(objects, JSONAPIError{
+			Status: strconv.Itoa(http.StatusUnprocessableEntity),
+			Code:   apperrors.SlugValidation,
+			Title:  message,
+			Detail: errors[field],
+			Source: &JSONAPISource{Pointer: "/" + field},
+		})
+	}
+	return JSONAPIDocument{Errors: objects}
+}
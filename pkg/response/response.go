@@ -1,52 +1,117 @@
 package response
 
 import (
+	"fmt"
+	"net"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
 
 	"github.com/gin-gonic/gin"
+	"github.com/your-username/go-clean-architecture/pkg/apperrors"
+	"github.com/your-username/go-clean-architecture/pkg/i18n"
+	"github.com/your-username/go-clean-architecture/pkg/pagination"
 )
 
 // Response represents the standard API response structure
 type Response struct {
 	Success bool        `json:"success"`
+	Code    string      `json:"code,omitempty"`
 	Message string      `json:"message"`
 	Data    interface{} `json:"data,omitempty"`
 	Error   interface{} `json:"error,omitempty"`
 	Meta    *Meta       `json:"meta,omitempty"`
 }
 
-// Meta holds pagination metadata
+// Meta holds pagination metadata. TotalType tells whether Total is exact,
+// estimated or omitted, in which case Total is -1 and TotalPages 0.
 type Meta struct {
-	CurrentPage int   `json:"current_page"`
-	PerPage     int   `json:"per_page"`
-	Total       int64 `json:"total"`
-	TotalPages  int   `json:"total_pages"`
+	CurrentPage int                  `json:"current_page"`
+	PerPage     int                  `json:"per_page"`
+	Total       int64                `json:"total"`
+	TotalType   pagination.TotalMode `json:"total_type"`
+	TotalPages  int                  `json:"total_pages"`
+	Links       *Links               `json:"links,omitempty"`
+}
+
+// Links holds absolute pagination URLs. Prev and Next are omitted on the
+// first and last page, and Last when the total is omitted.
+type Links struct {
+	First string `json:"first"`
+	Prev  string `json:"prev,omitempty"`
+	Next  string `json:"next,omitempty"`
+	Last  string `json:"last,omitempty"`
 }
 
 // Success sends a success response
-func Success(c *gin.Context, message string, data interface{}) {
+func Success(c *gin.Context, message i18n.Key, data interface{}) {
 	c.JSON(http.StatusOK, Response{
 		Success: true,
-		Message: message,
+		Message: i18n.T(i18n.FromContext(c), message),
 		Data:    data,
 	})
 }
 
 // SuccessWithMeta sends a success response with pagination meta
-func SuccessWithMeta(c *gin.Context, message string, data interface{}, meta *Meta) {
+func SuccessWithMeta(c *gin.Context, message i18n.Key, data interface{}, meta *Meta) {
 	c.JSON(http.StatusOK, Response{
 		Success: true,
-		Message: message,
+		Message: i18n.T(i18n.FromContext(c), message),
 		Data:    data,
 		Meta:    meta,
 	})
 }
 
+// Paginate sends a paginated success response. Page and limit are clamped to
+// valid values before the pagination meta is built.
+func Paginate[T any](c *gin.Context, items []T, page, limit int, total int64) {
+	PaginateWithMessage(c, i18n.MsgDataRetrieved, items, page, limit, total)
+}
+
+// PaginateWithMessage sends a paginated success response with a custom message
+func PaginateWithMessage[T any](c *gin.Context, message i18n.Key, items []T, page, limit int, total int64) {
+	PaginateWithTotal(c, message, items, page, limit, total, pagination.TotalExact)
+}
+
+// PaginateWithTotal sends a paginated success response whose total was
+// computed as mode says. Without an exact total the last page is not known
+// for sure, so prev and next links follow the current page, next being given
+// whenever the page is full.
+func PaginateWithTotal[T any](c *gin.Context, message i18n.Key, items []T, page, limit int, total int64, mode pagination.TotalMode) {
+	page, limit = pagination.Normalize(page, limit)
+
+	// Encode empty pages as [] rather than null
+	if items == nil {
+		items = []T{}
+	}
+
+	meta := BuildMetaWithLinks(c, page, limit, max(total, 0))
+	meta.TotalType = mode
+	if mode != pagination.TotalExact {
+		meta.Links.Prev, meta.Links.Next = "", ""
+		if page > 1 {
+			meta.Links.Prev = pageURL(c, page-1, limit)
+		}
+		if len(items) == limit {
+			meta.Links.Next = pageURL(c, page+1, limit)
+		}
+	}
+	if mode == pagination.TotalOmitted {
+		meta.Total = -1
+		meta.TotalPages = 0
+		meta.Links.Last = ""
+	}
+
+	SuccessWithMeta(c, message, items, meta)
+}
+
 // Created sends a created response
-func Created(c *gin.Context, message string, data interface{}) {
+func Created(c *gin.Context, message i18n.Key, data interface{}) {
 	c.JSON(http.StatusCreated, Response{
 		Success: true,
-		Message: message,
+		Message: i18n.T(i18n.FromContext(c), message),
 		Data:    data,
 	})
 }
@@ -57,69 +122,216 @@ func NoContent(c *gin.Context) {
 }
 
 // Error sends an error response
-func Error(c *gin.Context, statusCode int, message string, err interface{}) {
-	c.JSON(statusCode, Response{
-		Success: false,
-		Message: message,
-		Error:   err,
-	})
+func Error(c *gin.Context, statusCode int, message i18n.Key, err interface{}) {
+	ErrorWithCode(c, statusCode, "", message, err)
+}
+
+// ErrorWithCode sends an error response with a machine-readable error code
+func ErrorWithCode(c *gin.Context, statusCode int, code string, message i18n.Key, err interface{}) {
+	locale := i18n.FromContext(c)
+	text := i18n.T(locale, message)
+	if code != "" {
+		text = i18n.Error(locale, code, text)
+	}
+
+	formatter := errorFormatterFor(c)
+	writeError(c, formatter, statusCode, formatter.Error(statusCode, code, text, err))
+}
+
+// FromError sends an error response derived from an apperrors.AppError.
+// Errors that are not AppErrors are reported as internal server errors.
+func FromError(c *gin.Context, err error) {
+	appErr := apperrors.GetAppError(err)
+	ErrorWithCode(c, appErr.Code, appErr.Slug, i18n.Key(appErr.Message), nil)
 }
 
 // BadRequest sends a bad request error response
-func BadRequest(c *gin.Context, message string, err interface{}) {
+func BadRequest(c *gin.Context, message i18n.Key, err interface{}) {
 	Error(c, http.StatusBadRequest, message, err)
 }
 
 // Unauthorized sends an unauthorized error response
-func Unauthorized(c *gin.Context, message string) {
+func Unauthorized(c *gin.Context, message i18n.Key) {
 	Error(c, http.StatusUnauthorized, message, nil)
 }
 
 // Forbidden sends a forbidden error response
-func Forbidden(c *gin.Context, message string) {
+func Forbidden(c *gin.Context, message i18n.Key) {
 	Error(c, http.StatusForbidden, message, nil)
 }
 
 // NotFound sends a not found error response
-func NotFound(c *gin.Context, message string) {
+func NotFound(c *gin.Context, message i18n.Key) {
 	Error(c, http.StatusNotFound, message, nil)
 }
 
 // Conflict sends a conflict error response
-func Conflict(c *gin.Context, message string) {
+func Conflict(c *gin.Context, message i18n.Key) {
 	Error(c, http.StatusConflict, message, nil)
 }
 
 // UnprocessableEntity sends an unprocessable entity error response
-func UnprocessableEntity(c *gin.Context, message string, err interface{}) {
+func UnprocessableEntity(c *gin.Context, message i18n.Key, err interface{}) {
 	Error(c, http.StatusUnprocessableEntity, message, err)
 }
 
 // InternalServerError sends an internal server error response
-func InternalServerError(c *gin.Context, message string) {
+func InternalServerError(c *gin.Context, message i18n.Key) {
 	Error(c, http.StatusInternalServerError, message, nil)
 }
 
 // ValidationError sends a validation error response
 func ValidationError(c *gin.Context, errors map[string]string) {
-	c.JSON(http.StatusUnprocessableEntity, Response{
-		Success: false,
-		Message: "Validation failed",
-		Error:   errors,
-	})
+	formatter := errorFormatterFor(c)
+	message := i18n.T(i18n.FromContext(c), i18n.MsgValidationFailed)
+	writeError(c, formatter, http.StatusUnprocessableEntity, formatter.Validation(message, errors))
 }
 
-// BuildMeta creates pagination metadata
+// BuildMeta creates pagination metadata. A non-positive perPage falls back to
+// the default limit.
 func BuildMeta(page, perPage int, total int64) *Meta {
-	totalPages := int(total) / perPage
-	if int(total)%perPage > 0 {
-		totalPages++
+	if perPage <= 0 {
+		perPage = pagination.Limits().DefaultLimit
+	}
+
+	// Use int64 math so large totals don't truncate on 32-bit platforms
+	var totalPages int64
+	if total > 0 {
+		totalPages = (total + int64(perPage) - 1) / int64(perPage)
 	}
 
 	return &Meta{
 		CurrentPage: page,
 		PerPage:     perPage,
 		Total:       total,
-		TotalPages:  totalPages,
+		TotalType:   pagination.TotalExact,
+		TotalPages:  int(totalPages),
+	}
+}
+
+// BuildMetaWithLinks creates pagination metadata with first, prev, next and
+// last links built from the current request URL, keeping its other query
+// parameters.
+func BuildMetaWithLinks(c *gin.Context, page, perPage int, total int64) *Meta {
+	meta := BuildMeta(page, perPage, total)
+
+	lastPage := meta.TotalPages
+	if lastPage < 1 {
+		lastPage = 1
+	}
+
+	meta.Links = &Links{
+		First: pageURL(c, 1, meta.PerPage),
+		Last:  pageURL(c, lastPage, meta.PerPage),
+	}
+	if meta.CurrentPage > 1 {
+		// Past the end, prev points back to the last page
+		prev := meta.CurrentPage - 1
+		if prev > lastPage {
+			prev = lastPage
+		}
+		meta.Links.Prev = pageURL(c, prev, meta.PerPage)
+	}
+	if meta.CurrentPage < lastPage {
+		meta.Links.Next = pageURL(c, meta.CurrentPage+1, meta.PerPage)
+	}
+
+	return meta
+}
+
+var (
+	// linkOrigin replaces the request's scheme and host in pagination links
+	linkOrigin *url.URL
+	// linkProxies may set the scheme of pagination links through
+	// X-Forwarded-Proto
+	linkProxies   []*net.IPNet
+	linkOriginsMu sync.RWMutex
+)
+
+// SetLinkOrigin makes pagination links use the scheme and host of publicURL.
+// When it is empty, links use the request's scheme and host, and only the
+// trusted proxies, IPs or CIDRs, may change the scheme through
+// X-Forwarded-Proto; any other client could otherwise point links elsewhere.
+func SetLinkOrigin(publicURL string, trustedProxies []string) error {
+	var origin *url.URL
+	if publicURL != "" {
+		u, err := url.Parse(publicURL)
+		if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+			return fmt.Errorf("public URL must be an absolute http or https URL, got %q", publicURL)
+		}
+		origin = &url.URL{Scheme: u.Scheme, Host: u.Host}
+	}
+
+	proxies := make([]*net.IPNet, 0, len(trustedProxies))
+	for _, proxy := range trustedProxies {
+		cidr := proxy
+		if !strings.Contains(cidr, "/") {
+			if ip := net.ParseIP(cidr); ip != nil && ip.To4() != nil {
+				cidr += "/32"
+			} else {
+				cidr += "/128"
+			}
+		}
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return fmt.Errorf("trusted proxy must be an IP or CIDR, got %q", proxy)
+		}
+		proxies = append(proxies, network)
+	}
+
+	linkOriginsMu.Lock()
+	defer linkOriginsMu.Unlock()
+	linkOrigin = origin
+	linkProxies = proxies
+	return nil
+}
+
+// linkSchemeHost returns the scheme and host of pagination links
+func linkSchemeHost(c *gin.Context) (string, string) {
+	linkOriginsMu.RLock()
+	origin, proxies := linkOrigin, linkProxies
+	linkOriginsMu.RUnlock()
+
+	if origin != nil {
+		return origin.Scheme, origin.Host
+	}
+
+	scheme := "http"
+	if c.Request.TLS != nil {
+		scheme = "https"
+	} else if proto := c.GetHeader("X-Forwarded-Proto"); (proto == "https" || proto == "http") && fromProxy(c.RemoteIP(), proxies) {
+		scheme = proto
+	}
+	return scheme, c.Request.Host
+}
+
+// fromProxy reports whether the remote address is one of the proxies
+func fromProxy(remoteIP string, proxies []*net.IPNet) bool {
+	ip := net.ParseIP(remoteIP)
+	if ip == nil {
+		return false
+	}
+	for _, network := range proxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// pageURL returns the absolute URL of the current request for another page
+func pageURL(c *gin.Context, page, perPage int) string {
+	scheme, host := linkSchemeHost(c)
+
+	query := c.Request.URL.Query()
+	query.Set("page", strconv.Itoa(page))
+	query.Set("limit", strconv.Itoa(perPage))
+
+	u := url.URL{
+		Scheme:   scheme,
+		Host:     host,
+		Path:     c.Request.URL.Path,
+		RawQuery: query.Encode(),
 	}
+	return u.String()
 }
@@ -0,0 +1,138 @@
+package response
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-username/go-clean-architecture/config"
+	"github.com/your-username/go-clean-architecture/pkg/pagination"
+)
+
+func TestBuildMeta(t *testing.T) {
+	tests := []struct {
+		name           string
+		page           int
+		perPage        int
+		total          int64
+		wantPerPage    int
+		wantTotalPages int
+	}{
+		{name: "partial last page", page: 1, perPage: 10, total: 25, wantPerPage: 10, wantTotalPages: 3},
+		{name: "exact multiple of per page", page: 2, perPage: 10, total: 30, wantPerPage: 10, wantTotalPages: 3},
+		{name: "fewer than one page", page: 1, perPage: 10, total: 3, wantPerPage: 10, wantTotalPages: 1},
+		{name: "no results", page: 1, perPage: 10, total: 0, wantPerPage: 10, wantTotalPages: 0},
+		{name: "zero per page uses the default", page: 1, perPage: 0, total: 45, wantPerPage: 20, wantTotalPages: 3},
+		{name: "negative per page uses the default", page: 1, perPage: -5, total: 20, wantPerPage: 20, wantTotalPages: 1},
+		{name: "zero per page and no results", page: 1, perPage: 0, total: 0, wantPerPage: 20, wantTotalPages: 0},
+		{name: "total beyond int32", page: 1, perPage: 1000, total: 3_000_000_000, wantPerPage: 1000, wantTotalPages: 3_000_000},
+	}
+
+	pagination.SetLimits(config.PaginationConfig{DefaultPage: 1, DefaultLimit: 20, MaxLimit: 100})
+	t.Cleanup(func() { pagination.SetLimits(config.PaginationConfig{}) })
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			meta := BuildMeta(tt.page, tt.perPage, tt.total)
+			if meta.CurrentPage != tt.page || meta.Total != tt.total {
+				t.Errorf("BuildMeta() page %d total %d, want page %d total %d", meta.CurrentPage, meta.Total, tt.page, tt.total)
+			}
+			if meta.PerPage != tt.wantPerPage {
+				t.Errorf("BuildMeta() per page = %d, want %d", meta.PerPage, tt.wantPerPage)
+			}
+			if meta.TotalPages != tt.wantTotalPages {
+				t.Errorf("BuildMeta() total pages = %d, want %d", meta.TotalPages, tt.wantTotalPages)
+			}
+			if meta.TotalType != pagination.TotalExact {
+				t.Errorf("BuildMeta() total type = %s, want %s", meta.TotalType, pagination.TotalExact)
+			}
+		})
+	}
+}
+
+// linkContext returns a context for a list request sent from remoteAddr
+func linkContext(remoteAddr string, headers map[string]string) *gin.Context {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("GET", "http://api.internal/users?status=active", nil)
+	c.Request.RemoteAddr = remoteAddr
+	for name, value := range headers {
+		c.Request.Header.Set(name, value)
+	}
+	return c
+}
+
+func TestPageURLOrigin(t *testing.T) {
+	tests := []struct {
+		name       string
+		publicURL  string
+		remoteAddr string
+		headers    map[string]string
+		want       string
+	}{
+		{
+			name:       "plain request",
+			remoteAddr: "203.0.113.7:5000",
+			want:       "http://api.internal/users?limit=10&page=2&status=active",
+		},
+		{
+			name:       "forwarded proto from an untrusted client",
+			remoteAddr: "203.0.113.7:5000",
+			headers:    map[string]string{"X-Forwarded-Proto": "https", "X-Forwarded-Host": "evil.example"},
+			want:       "http://api.internal/users?limit=10&page=2&status=active",
+		},
+		{
+			name:       "forwarded proto from a trusted proxy",
+			remoteAddr: "10.0.0.5:5000",
+			headers:    map[string]string{"X-Forwarded-Proto": "https"},
+			want:       "https://api.internal/users?limit=10&page=2&status=active",
+		},
+		{
+			name:       "unknown forwarded proto from a trusted proxy",
+			remoteAddr: "10.0.0.5:5000",
+			headers:    map[string]string{"X-Forwarded-Proto": "javascript"},
+			want:       "http://api.internal/users?limit=10&page=2&status=active",
+		},
+		{
+			name:       "public URL",
+			publicURL:  "https://api.example.com",
+			remoteAddr: "10.0.0.5:5000",
+			headers:    map[string]string{"X-Forwarded-Proto": "http"},
+			want:       "https://api.example.com/users?limit=10&page=2&status=active",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := SetLinkOrigin(tt.publicURL, []string{"10.0.0.0/8", "::1"}); err != nil {
+				t.Fatalf("SetLinkOrigin() error = %v", err)
+			}
+			t.Cleanup(func() { _ = SetLinkOrigin("", nil) })
+
+			if got := pageURL(linkContext(tt.remoteAddr, tt.headers), 2, 10); got != tt.want {
+				t.Errorf("pageURL() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetLinkOriginRejectsInvalidSettings(t *testing.T) {
+	tests := []struct {
+		name           string
+		publicURL      string
+		trustedProxies []string
+	}{
+		{name: "relative public URL", publicURL: "api.example.com"},
+		{name: "public URL with another scheme", publicURL: "ftp://api.example.com"},
+		{name: "malformed proxy", trustedProxies: []string{"10.0.0.0/33"}},
+		{name: "proxy host name", trustedProxies: []string{"proxy.internal"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(func() { _ = SetLinkOrigin("", nil) })
+			if err := SetLinkOrigin(tt.publicURL, tt.trustedProxies); err == nil {
+				t.Error("SetLinkOrigin() error = nil, want an error")
+			}
+		})
+	}
+}
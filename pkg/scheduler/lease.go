@@ -0,0 +1,42 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/your-username/go-clean-architecture/pkg/database"
+)
+
+// leaseKey is the key of the scheduler's leader lease
+const leaseKey = "scheduler:leader"
+
+// Lease elects the replica that runs the jobs among the replicas sharing it
+type Lease interface {
+	// Acquire tries once to take the lease for replica, or to renew it when
+	// replica already holds it, and returns the replica holding it afterwards
+	Acquire(ctx context.Context, replica string) (holder string, err error)
+	// Release gives up the lease while replica holds it
+	Release(replica string)
+}
+
+// redisLease is a lease stored in Redis
+type redisLease struct {
+	client *database.RedisClient
+	ttl    time.Duration
+}
+
+// NewRedisLease creates a lease that expires ttl after its holder last
+// renewed it
+func NewRedisLease(client *database.RedisClient, ttl time.Duration) Lease {
+	return &redisLease{client: client, ttl: ttl}
+}
+
+// Acquire takes or renews the Redis lease
+func (l *redisLease) Acquire(ctx context.Context, replica string) (string, error) {
+	return l.client.AcquireLease(ctx, leaseKey, replica, l.ttl)
+}
+
+// Release deletes the Redis lease
+func (l *redisLease) Release(replica string) {
+	l.client.ReleaseLease(leaseKey, replica)
+}
@@ -0,0 +1,266 @@
+// Package scheduler runs recurring jobs in the background
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/your-username/go-clean-architecture/config"
+	"github.com/your-username/go-clean-architecture/pkg/database"
+	"github.com/your-username/go-clean-architecture/pkg/logger"
+)
+
+// JobFunc is the work of a job. Its context is cancelled on shutdown.
+type JobFunc func(ctx context.Context) error
+
+// job is a registered job
+type job struct {
+	name     string
+	interval time.Duration
+	fn       JobFunc
+	running  atomic.Bool
+}
+
+// Scheduler runs every registered job at its interval. A job whose previous
+// run is still going when its next run is due skips that run, so runs of the
+// same job never overlap. With a lease, the replicas sharing it elect a leader
+// and only the leader runs jobs. The leader renews the lease every renew
+// interval for as long as it runs; when it stops or dies, the lease expires
+// after its TTL and another replica takes over.
+type Scheduler struct {
+	jobs    []*job
+	lease   Lease
+	ttl     time.Duration
+	renew   time.Duration
+	replica string
+	ctx     context.Context
+	cancel  context.CancelFunc
+	started bool
+	wg      sync.WaitGroup
+
+	leaderMu sync.Mutex
+	// leaderCtx is set while this replica holds the lease and is cancelled
+	// when it loses it, so running jobs stop
+	leaderCtx    context.Context
+	leaderCancel context.CancelFunc
+}
+
+// New creates a scheduler whose jobs run on the replica holding a Redis
+// lease, or on every replica when Redis is unavailable
+func New(cfg config.SchedulerConfig, client *database.RedisClient) *Scheduler {
+	if client == nil {
+		logger.Warn("Redis unavailable, scheduled jobs run on every instance")
+		return NewWithLease(nil, cfg.ReplicaID, cfg.LockTTL, cfg.LockRenewInterval)
+	}
+	return NewWithLease(NewRedisLease(client, cfg.LockTTL), cfg.ReplicaID, cfg.LockTTL, cfg.LockRenewInterval)
+}
+
+// NewWithLease creates a scheduler that runs jobs while replica holds lease,
+// which may be nil to run jobs on every replica. The lease expires ttl after
+// it was last renewed and is renewed every renew interval, which must be
+// shorter than ttl. replica must be unique among the replicas.
+func NewWithLease(lease Lease, replica string, ttl, renew time.Duration) *Scheduler {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Scheduler{lease: lease, ttl: ttl, renew: renew, replica: replica, ctx: ctx, cancel: cancel}
+}
+
+// Register adds a job running fn every interval, the first run being one
+// interval after Start. Jobs must be registered before Start.
+func (s *Scheduler) Register(name string, interval time.Duration, fn JobFunc) {
+	if s.started {
+		panic("scheduler: Register called after Start")
+	}
+	if interval <= 0 {
+		panic("scheduler: interval of job " + name + " must be positive")
+	}
+	s.jobs = append(s.jobs, &job{name: name, interval: interval, fn: fn})
+}
+
+// Start starts running the registered jobs and, with a lease, campaigning
+// for it
+func (s *Scheduler) Start() {
+	s.started = true
+	if s.lease != nil {
+		s.wg.Add(1)
+		go s.campaign()
+	}
+	for _, j := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(j)
+	}
+	logger.WithField("replica", s.replica).Infof("Scheduler started with %d jobs", len(s.jobs))
+}
+
+// Stop stops scheduling runs, cancels the context of running jobs and waits
+// for them to return, or for ctx to be done
+func (s *Scheduler) Stop(ctx context.Context) error {
+	s.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// campaign takes the lease, or renews it while this replica leads, every
+// renew interval until the scheduler stops, then releases it
+func (s *Scheduler) campaign() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.renew)
+	defer ticker.Stop()
+
+	log := logger.WithField("replica", s.replica)
+	var expires time.Time
+	var lastHolder string
+	for {
+		attempted := time.Now()
+		holder, err := s.lease.Acquire(s.ctx, s.replica)
+		switch {
+		case s.ctx.Err() != nil:
+		case err != nil:
+			// Transient failures are retried while the lease is still held
+			if s.leading() && time.Until(expires) <= s.renew {
+				log.WithField("error", err).Warn("Lost the scheduler lease, it could not be renewed before expiring")
+				s.stepDown()
+			} else {
+				log.WithField("error", err).Warn("Failed to take or renew the scheduler lease")
+			}
+		case holder == s.replica:
+			expires = attempted.Add(s.ttl)
+			if s.takeLead() {
+				log.Info("Scheduler lease taken, jobs run on this replica")
+			}
+		default:
+			if s.stepDown() {
+				log.WithField("holder", holder).Warn("Lost the scheduler lease to another replica")
+			} else if holder != lastHolder {
+				log.WithField("holder", holder).Info("Scheduler lease held by another replica, jobs run there")
+			}
+		}
+		if err == nil {
+			lastHolder = holder
+		}
+
+		select {
+		case <-s.ctx.Done():
+			if s.stepDown() {
+				s.lease.Release(s.replica)
+				log.Info("Scheduler lease released")
+			}
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// takeLead records that this replica holds the lease and reports whether it
+// just took it
+func (s *Scheduler) takeLead() bool {
+	s.leaderMu.Lock()
+	defer s.leaderMu.Unlock()
+	if s.leaderCtx != nil {
+		return false
+	}
+	s.leaderCtx, s.leaderCancel = context.WithCancel(s.ctx)
+	return true
+}
+
+// stepDown cancels the jobs run under the lease and reports whether this
+// replica held it
+func (s *Scheduler) stepDown() bool {
+	s.leaderMu.Lock()
+	defer s.leaderMu.Unlock()
+	if s.leaderCtx == nil {
+		return false
+	}
+	s.leaderCancel()
+	s.leaderCtx, s.leaderCancel = nil, nil
+	return true
+}
+
+// leading reports whether this replica holds the lease
+func (s *Scheduler) leading() bool {
+	return s.leaderContext() != nil
+}
+
+// leaderContext returns the context of jobs run under the lease, or nil when
+// another replica holds it
+func (s *Scheduler) leaderContext() context.Context {
+	s.leaderMu.Lock()
+	defer s.leaderMu.Unlock()
+	return s.leaderCtx
+}
+
+// loop triggers the job at its interval until the scheduler stops
+func (s *Scheduler) loop(j *job) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if !j.running.CompareAndSwap(false, true) {
+				s.log(j).Warn("Job skipped, its previous run is still in progress")
+				continue
+			}
+			s.wg.Add(1)
+			go s.run(j)
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// run runs the job once, logging its start, outcome and duration. A panic
+// is logged rather than crashing the process.
+func (s *Scheduler) run(j *job) {
+	defer s.wg.Done()
+	defer j.running.Store(false)
+
+	log := s.log(j)
+
+	ctx := s.ctx
+	if s.lease != nil {
+		if ctx = s.leaderContext(); ctx == nil {
+			log.Debug("Job skipped, another replica holds the scheduler lease")
+			return
+		}
+	}
+
+	start := time.Now()
+	log.Info("Job started")
+
+	defer func() {
+		if r := recover(); r != nil {
+			log.WithField("duration", time.Since(start).String()).Errorf("Job panicked: %v", r)
+		}
+	}()
+
+	if err := j.fn(ctx); err != nil {
+		log.WithFields(logrus.Fields{
+			"duration": time.Since(start).String(),
+			"error":    err,
+		}).Error("Job failed")
+		return
+	}
+	log.WithField("duration", time.Since(start).String()).Info("Job finished")
+}
+
+// log returns a log entry for the job on this replica
+func (s *Scheduler) log(j *job) *logrus.Entry {
+	return logger.WithFields(logrus.Fields{"job": j.name, "replica": s.replica})
+}
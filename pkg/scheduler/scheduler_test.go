@@ -0,0 +1,153 @@
+package scheduler
+
+import (
+	"context"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/your-username/go-clean-architecture/pkg/logger"
+)
+
+func TestMain(m *testing.M) {
+	logger.InitLogger(false)
+	os.Exit(m.Run())
+}
+
+// memoryLease is a lease shared in memory by the schedulers of a test
+type memoryLease struct {
+	mu      sync.Mutex
+	holder  string
+	expires time.Time
+	ttl     time.Duration
+}
+
+func (l *memoryLease) Acquire(_ context.Context, replica string) (string, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.holder == "" || l.holder == replica || time.Now().After(l.expires) {
+		l.holder = replica
+		l.expires = time.Now().Add(l.ttl)
+	}
+	return l.holder, nil
+}
+
+func (l *memoryLease) Release(replica string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.holder == replica {
+		l.holder = ""
+	}
+}
+
+func (l *memoryLease) current() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.holder
+}
+
+const (
+	testTTL      = 300 * time.Millisecond
+	testRenew    = 50 * time.Millisecond
+	testInterval = 20 * time.Millisecond
+)
+
+// startCounting starts a scheduler for replica whose job counts its runs
+func startCounting(t *testing.T, lease Lease, replica string, runs *atomic.Int64) *Scheduler {
+	t.Helper()
+	s := NewWithLease(lease, replica, testTTL, testRenew)
+	s.Register("count", testInterval, func(context.Context) error {
+		runs.Add(1)
+		return nil
+	})
+	s.Start()
+	return s
+}
+
+func stop(t *testing.T, s *Scheduler) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := s.Stop(ctx); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+}
+
+func TestSchedulerRunsJobsOnLeaseHolderOnly(t *testing.T) {
+	lease := &memoryLease{ttl: testTTL}
+	var runsA, runsB atomic.Int64
+
+	a := startCounting(t, lease, "a", &runsA)
+	defer stop(t, a)
+	// The second replica's ticks are offset from the first's
+	time.Sleep(testInterval / 2)
+	b := startCounting(t, lease, "b", &runsB)
+	defer stop(t, b)
+
+	time.Sleep(10 * testInterval)
+
+	if got := lease.current(); got != "a" {
+		t.Fatalf("lease holder = %q, want %q", got, "a")
+	}
+	if runsA.Load() == 0 {
+		t.Error("leader ran no jobs")
+	}
+	if n := runsB.Load(); n != 0 {
+		t.Errorf("follower ran %d jobs, want 0", n)
+	}
+}
+
+func TestSchedulerKeepsLeaseAcrossRuns(t *testing.T) {
+	lease := &memoryLease{ttl: testTTL}
+	var runs atomic.Int64
+
+	s := startCounting(t, lease, "a", &runs)
+	defer stop(t, s)
+
+	// Well past the TTL, the lease is still held thanks to renewals
+	time.Sleep(2 * testTTL)
+
+	if got := lease.current(); got != "a" {
+		t.Errorf("lease holder = %q, want %q", got, "a")
+	}
+}
+
+func TestSchedulerHandsOverLeaseOnStop(t *testing.T) {
+	lease := &memoryLease{ttl: testTTL}
+	var runsA, runsB atomic.Int64
+
+	a := startCounting(t, lease, "a", &runsA)
+	b := startCounting(t, lease, "b", &runsB)
+	defer stop(t, b)
+
+	time.Sleep(5 * testInterval)
+	stop(t, a)
+	ranA := runsA.Load()
+
+	// b takes the released lease at its next renewal
+	time.Sleep(2*testRenew + 5*testInterval)
+
+	if got := lease.current(); got != "b" {
+		t.Fatalf("lease holder = %q, want %q", got, "b")
+	}
+	if runsB.Load() == 0 {
+		t.Error("new leader ran no jobs")
+	}
+	if n := runsA.Load(); n != ranA {
+		t.Errorf("stopped replica ran %d more jobs", n-ranA)
+	}
+}
+
+func TestSchedulerWithoutLeaseRunsJobs(t *testing.T) {
+	var runs atomic.Int64
+
+	s := startCounting(t, nil, "a", &runs)
+	time.Sleep(5 * testInterval)
+	stop(t, s)
+
+	if runs.Load() == 0 {
+		t.Error("scheduler without a lease ran no jobs")
+	}
+}
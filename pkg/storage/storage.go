@@ -0,0 +1,40 @@
+// Package storage stores uploaded files behind a pluggable backend
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/your-username/go-clean-architecture/config"
+)
+
+// Storage drivers
+const (
+	DriverLocal = "local"
+)
+
+// ErrInvalidKey is returned for keys that escape the storage root
+var ErrInvalidKey = errors.New("storage: invalid key")
+
+// Storage stores files under slash-separated keys such as "avatars/1.png".
+// Implementations must be safe for concurrent use.
+type Storage interface {
+	// Put stores the content under key, replacing any existing file
+	Put(ctx context.Context, key string, content io.Reader, contentType string) error
+	// Delete removes the file under key. Deleting a missing key is not an error.
+	Delete(ctx context.Context, key string) error
+	// URL returns the public URL of the file under key
+	URL(key string) string
+}
+
+// NewStorage creates the Storage selected by the STORAGE_DRIVER configuration
+func NewStorage(cfg *config.StorageConfig) (Storage, error) {
+	switch cfg.Driver {
+	case "", DriverLocal:
+		return NewLocalStorage(cfg.LocalDir, cfg.BaseURL)
+	default:
+		return nil, fmt.Errorf("unknown storage driver: %s", cfg.Driver)
+	}
+}
@@ -1,57 +1,137 @@
 package utils
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/your-username/go-clean-architecture/config"
 )
 
+// ErrUnknownSigningSecret is returned for tokens whose signature matches
+// neither the current secret nor any previous one. It wraps
+// jwt.ErrTokenSignatureInvalid.
+var ErrUnknownSigningSecret = fmt.Errorf("%w: token was not signed with the current or a previous JWT secret", jwt.ErrTokenSignatureInvalid)
+
 // JWTClaims represents JWT claims
 type JWTClaims struct {
 	UserID uint   `json:"user_id"`
 	Email  string `json:"email"`
 	Role   string `json:"role"`
+	// TokenVersion must match the user's current version for the token to
+	// be accepted
+	TokenVersion uint `json:"ver"`
 	jwt.RegisteredClaims
 }
 
-// JWTManager handles JWT operations
+// Validate implements jwt.ClaimsValidator. Every token this manager issues
+// carries iat and nbf, so a token without them is rejected.
+func (c *JWTClaims) Validate() error {
+	if c.IssuedAt == nil {
+		return fmt.Errorf("%w: iat claim is required", jwt.ErrTokenRequiredClaimMissing)
+	}
+	if c.NotBefore == nil {
+		return fmt.Errorf("%w: nbf claim is required", jwt.ErrTokenRequiredClaimMissing)
+	}
+	return nil
+}
+
+// JWTManager handles JWT operations. Tokens are signed with the current
+// secret, while previous secrets are still accepted for validation so tokens
+// issued before a rotation keep working until they expire.
 type JWTManager struct {
 	secret     string
 	expiration time.Duration
+	issuer     string
+	audience   string
+	// verificationKeys holds the current secret followed by the previous ones
+	verificationKeys jwt.VerificationKeySet
+	parserOptions    []jwt.ParserOption
 }
 
-// NewJWTManager creates a new JWT manager
-func NewJWTManager(secret string, expiration time.Duration) *JWTManager {
+// NewJWTManager creates a new JWT manager. Previous secrets are only used to
+// validate tokens, in the order given. Validation requires the configured
+// issuer and audience, when set, and allows cfg.Leeway of clock skew on exp,
+// nbf and iat, so a token issued further in the future is rejected.
+func NewJWTManager(cfg config.JWTConfig) *JWTManager {
+	keys := make([]jwt.VerificationKey, 0, 1+len(cfg.PreviousSecrets))
+	keys = append(keys, []byte(cfg.Secret))
+	for _, previous := range cfg.PreviousSecrets {
+		keys = append(keys, []byte(previous))
+	}
+
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(cfg.Leeway),
+	}
+	if cfg.Issuer != "" {
+		options = append(options, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		options = append(options, jwt.WithAudience(cfg.Audience))
+	}
+
 	return &JWTManager{
-		secret:     secret,
-		expiration: expiration,
+		secret:           cfg.Secret,
+		expiration:       cfg.ExpireHours,
+		issuer:           cfg.Issuer,
+		audience:         cfg.Audience,
+		verificationKeys: jwt.VerificationKeySet{Keys: keys},
+		parserOptions:    options,
 	}
 }
 
 // GenerateToken generates a new JWT token
-func (j *JWTManager) GenerateToken(userID uint, email, role string) (string, error) {
+func (j *JWTManager) GenerateToken(userID uint, email, role string, tokenVersion uint) (string, error) {
+	token, _, err := j.GenerateTokenWithExpiry(userID, email, role, tokenVersion)
+	return token, err
+}
+
+// GenerateTokenWithExpiry generates a new JWT token and returns when it expires
+func (j *JWTManager) GenerateTokenWithExpiry(userID uint, email, role string, tokenVersion uint) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(j.expiration)
+
 	claims := JWTClaims{
-		UserID: userID,
-		Email:  email,
-		Role:   role,
+		UserID:       userID,
+		Email:        email,
+		Role:         role,
+		TokenVersion: tokenVersion,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(j.expiration)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			NotBefore: jwt.NewNumericDate(time.Now()),
+			Issuer:    j.issuer,
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
 		},
 	}
+	if j.audience != "" {
+		claims.Audience = jwt.ClaimStrings{j.audience}
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(j.secret))
+	if err != nil {
+		return "", time.Time{}, err
+	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(j.secret))
+	// The exp claim has second precision, report exactly what the token says
+	return token, claims.ExpiresAt.Time, nil
 }
 
-// ValidateToken validates a JWT token
+// ValidateToken validates a JWT token, trying the current secret first and
+// then each previous secret. A token signed with none of them fails with
+// ErrUnknownSigningSecret.
 func (j *JWTManager) ValidateToken(tokenString string) (*JWTClaims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
-		return []byte(j.secret), nil
-	})
+		return j.verificationKeys, nil
+	}, j.parserOptions...)
 
 	if err != nil {
+		if errors.Is(err, jwt.ErrTokenSignatureInvalid) {
+			return nil, ErrUnknownSigningSecret
+		}
 		return nil, err
 	}
 
@@ -69,5 +149,5 @@ func (j *JWTManager) RefreshToken(tokenString string) (string, error) {
 		return "", err
 	}
 
-	return j.GenerateToken(claims.UserID, claims.Email, claims.Role)
+	return j.GenerateToken(claims.UserID, claims.Email, claims.Role, claims.TokenVersion)
 }
@@ -0,0 +1,153 @@
+package utils
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/your-username/go-clean-architecture/config"
+)
+
+// testJWTConfig tolerates 30 seconds of clock skew
+func testJWTConfig() config.JWTConfig {
+	return config.JWTConfig{
+		Secret:          "current-secret",
+		ExpireHours:     time.Hour,
+		PreviousSecrets: []string{"previous-secret"},
+		Issuer:          "go-clean-architecture",
+		Audience:        "api",
+		Leeway:          30 * time.Second,
+	}
+}
+
+// signClaims returns a token for user 1 signed with secret, its registered
+// claims changed by edit from those of a token issued now
+func signClaims(t *testing.T, secret string, edit func(*jwt.RegisteredClaims)) string {
+	t.Helper()
+	now := time.Now()
+	claims := JWTClaims{
+		UserID: 1,
+		Email:  "jane@example.com",
+		Role:   "user",
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    "go-clean-architecture",
+			Audience:  jwt.ClaimStrings{"api"},
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+		},
+	}
+	if edit != nil {
+		edit(&claims.RegisteredClaims)
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return token
+}
+
+// in returns a NumericDate d from now
+func in(d time.Duration) *jwt.NumericDate {
+	return jwt.NewNumericDate(time.Now().Add(d))
+}
+
+func TestGenerateTokenSetsRegisteredClaims(t *testing.T) {
+	m := NewJWTManager(testJWTConfig())
+	token, err := m.GenerateToken(1, "jane@example.com", "user", 3)
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+
+	claims, err := m.ValidateToken(token)
+	if err != nil {
+		t.Fatalf("ValidateToken() error = %v", err)
+	}
+	if claims.IssuedAt == nil || claims.NotBefore == nil || claims.ExpiresAt == nil {
+		t.Fatalf("token claims iat %v, nbf %v, exp %v; want all set", claims.IssuedAt, claims.NotBefore, claims.ExpiresAt)
+	}
+	if claims.Issuer != "go-clean-architecture" || len(claims.Audience) != 1 || claims.Audience[0] != "api" {
+		t.Errorf("token claims iss %q, aud %v; want go-clean-architecture and [api]", claims.Issuer, claims.Audience)
+	}
+	if claims.UserID != 1 || claims.TokenVersion != 3 {
+		t.Errorf("token claims user %d, version %d; want 1 and 3", claims.UserID, claims.TokenVersion)
+	}
+}
+
+func TestValidateTokenClockSkew(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(*jwt.RegisteredClaims)
+		want error
+	}{
+		{name: "issued now"},
+		{name: "nbf in the future within leeway", edit: func(c *jwt.RegisteredClaims) { c.NotBefore = in(10 * time.Second) }},
+		{name: "nbf in the future beyond leeway", edit: func(c *jwt.RegisteredClaims) { c.NotBefore = in(2 * time.Minute) }, want: jwt.ErrTokenNotValidYet},
+		{name: "iat in the future within leeway", edit: func(c *jwt.RegisteredClaims) { c.IssuedAt = in(10 * time.Second) }},
+		{name: "iat in the future beyond leeway", edit: func(c *jwt.RegisteredClaims) { c.IssuedAt = in(2 * time.Minute) }, want: jwt.ErrTokenUsedBeforeIssued},
+		{name: "expired within leeway", edit: func(c *jwt.RegisteredClaims) { c.ExpiresAt = in(-10 * time.Second) }},
+		{name: "expired beyond leeway", edit: func(c *jwt.RegisteredClaims) { c.ExpiresAt = in(-2 * time.Minute) }, want: jwt.ErrTokenExpired},
+		{name: "missing iat", edit: func(c *jwt.RegisteredClaims) { c.IssuedAt = nil }, want: jwt.ErrTokenRequiredClaimMissing},
+		{name: "missing nbf", edit: func(c *jwt.RegisteredClaims) { c.NotBefore = nil }, want: jwt.ErrTokenRequiredClaimMissing},
+		{name: "missing exp", edit: func(c *jwt.RegisteredClaims) { c.ExpiresAt = nil }, want: jwt.ErrTokenRequiredClaimMissing},
+	}
+
+	m := NewJWTManager(testJWTConfig())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := m.ValidateToken(signClaims(t, "current-secret", tt.edit))
+			if !errors.Is(err, tt.want) || (tt.want == nil && err != nil) {
+				t.Errorf("ValidateToken() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateTokenIssuerAndAudience(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(*jwt.RegisteredClaims)
+		want error
+	}{
+		{name: "other issuer", edit: func(c *jwt.RegisteredClaims) { c.Issuer = "someone-else" }, want: jwt.ErrTokenInvalidIssuer},
+		{name: "missing issuer", edit: func(c *jwt.RegisteredClaims) { c.Issuer = "" }, want: jwt.ErrTokenRequiredClaimMissing},
+		{name: "other audience", edit: func(c *jwt.RegisteredClaims) { c.Audience = jwt.ClaimStrings{"admin-api"} }, want: jwt.ErrTokenInvalidAudience},
+		{name: "missing audience", edit: func(c *jwt.RegisteredClaims) { c.Audience = nil }, want: jwt.ErrTokenRequiredClaimMissing},
+		{name: "one of several audiences", edit: func(c *jwt.RegisteredClaims) { c.Audience = jwt.ClaimStrings{"admin-api", "api"} }},
+	}
+
+	m := NewJWTManager(testJWTConfig())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := m.ValidateToken(signClaims(t, "current-secret", tt.edit))
+			if !errors.Is(err, tt.want) || (tt.want == nil && err != nil) {
+				t.Errorf("ValidateToken() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	t.Run("not required when unset", func(t *testing.T) {
+		cfg := testJWTConfig()
+		cfg.Issuer, cfg.Audience = "", ""
+		token := signClaims(t, "current-secret", func(c *jwt.RegisteredClaims) {
+			c.Issuer = "someone-else"
+			c.Audience = nil
+		})
+		if _, err := NewJWTManager(cfg).ValidateToken(token); err != nil {
+			t.Errorf("ValidateToken() error = %v, want nil", err)
+		}
+	})
+}
+
+func TestValidateTokenSigningSecrets(t *testing.T) {
+	m := NewJWTManager(testJWTConfig())
+
+	if _, err := m.ValidateToken(signClaims(t, "previous-secret", nil)); err != nil {
+		t.Errorf("ValidateToken() of a token signed with a previous secret error = %v", err)
+	}
+	if _, err := m.ValidateToken(signClaims(t, "unknown-secret", nil)); !errors.Is(err, ErrUnknownSigningSecret) {
+		t.Errorf("ValidateToken() of a token signed with an unknown secret error = %v, want ErrUnknownSigningSecret", err)
+	}
+}
@@ -2,9 +2,21 @@ package utils
 
 import "golang.org/x/crypto/bcrypt"
 
+// passwordCost is the bcrypt cost used for new hashes
+var passwordCost = bcrypt.DefaultCost
+
+// SetPasswordCost sets the bcrypt cost used by HashPassword. Costs outside
+// bcrypt's allowed range fall back to the default.
+func SetPasswordCost(cost int) {
+	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		cost = bcrypt.DefaultCost
+	}
+	passwordCost = cost
+}
+
 // HashPassword hashes a password using bcrypt
 func HashPassword(password string) (string, error) {
-	bytes, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	bytes, err := bcrypt.GenerateFromPassword([]byte(password), passwordCost)
 	return string(bytes), err
 }
 
@@ -13,3 +25,13 @@ func CheckPassword(password, hash string) bool {
 	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
 	return err == nil
 }
+
+// NeedsRehash reports whether a hash was made with a lower cost than the one
+// currently configured, so it should be replaced on the next successful login
+func NeedsRehash(hash string) bool {
+	cost, err := bcrypt.Cost([]byte(hash))
+	if err != nil {
+		return false
+	}
+	return cost < passwordCost
+}
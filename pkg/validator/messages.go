@@ -0,0 +1,65 @@
+package validator
+
+import "github.com/your-username/go-clean-architecture/pkg/i18n"
+
+// messages holds validation messages per locale, keyed by validation tag.
+// {param} and {field} are replaced with the tag parameter and field name.
+var messages = map[string]map[string]string{
+	i18n.LocaleEnglish: {
+		"required":                "This field is required",
+		"email":                   "Invalid email format",
+		"unique_email":            "Email is already registered",
+		"min":                     "Value is too short",
+		"max":                     "Value is too long",
+		"gte":                     "Value must be greater than or equal to {param}",
+		"lte":                     "Value must be less than or equal to {param}",
+		"oneof":                   "Value must be one of: {param}",
+		"url":                     "Invalid URL format",
+		"uuid":                    "Invalid UUID format",
+		"numeric":                 "Value must be numeric",
+		"boolean":                 "Value must be true or false",
+		"alpha":                   "Value must contain only letters",
+		"alphanum":                "Value must contain only letters and numbers",
+		"null_only":               "Only null is accepted, which removes the value",
+		"default":                 "Invalid value for {field}",
+		"strong_password":         "Password must be at least {param} characters",
+		"strong_password.contain": " and contain ",
+		"strong_password.upper":   "an uppercase letter",
+		"strong_password.lower":   "a lowercase letter",
+		"strong_password.digit":   "a digit",
+		"strong_password.special": "a special character",
+	},
+	i18n.LocaleIndonesian: {
+		"required":                "Kolom ini wajib diisi",
+		"email":                   "Format email tidak valid",
+		"unique_email":            "Email sudah terdaftar",
+		"min":                     "Nilai terlalu pendek",
+		"max":                     "Nilai terlalu panjang",
+		"gte":                     "Nilai harus lebih besar dari atau sama dengan {param}",
+		"lte":                     "Nilai harus lebih kecil dari atau sama dengan {param}",
+		"oneof":                   "Nilai harus salah satu dari: {param}",
+		"url":                     "Format URL tidak valid",
+		"uuid":                    "Format UUID tidak valid",
+		"numeric":                 "Nilai harus berupa angka",
+		"boolean":                 "Nilai harus true atau false",
+		"alpha":                   "Nilai hanya boleh berisi huruf",
+		"alphanum":                "Nilai hanya boleh berisi huruf dan angka",
+		"null_only":               "Hanya null yang diterima, yang menghapus nilainya",
+		"default":                 "Nilai tidak valid untuk {field}",
+		"strong_password":         "Kata sandi minimal {param} karakter",
+		"strong_password.contain": " dan harus mengandung ",
+		"strong_password.upper":   "huruf besar",
+		"strong_password.lower":   "huruf kecil",
+		"strong_password.digit":   "angka",
+		"strong_password.special": "karakter khusus",
+	},
+}
+
+// translate looks up a message for the locale, falling back to English
+func translate(locale, key string) (string, bool) {
+	if msg, ok := messages[locale][key]; ok {
+		return msg, true
+	}
+	msg, ok := messages[i18n.DefaultLocale][key]
+	return msg, ok
+}
@@ -1,33 +1,54 @@
 package validator
 
 import (
+	"context"
+	"net/url"
 	"reflect"
+	"strconv"
 	"strings"
+	"time"
+	"unicode"
 
 	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
+	"github.com/your-username/go-clean-architecture/config"
 )
 
+// dbValidationTimeout bounds database lookups made by validators
+const dbValidationTimeout = 5 * time.Second
+
+// passwordPolicy is the policy enforced by the strong_password tag
+var passwordPolicy = config.PasswordPolicyConfig{
+	MinLength:      8,
+	RequireUpper:   true,
+	RequireLower:   true,
+	RequireDigit:   true,
+	RequireSpecial: true,
+}
+
+// SetPasswordPolicy sets the policy enforced by the strong_password tag
+func SetPasswordPolicy(policy config.PasswordPolicyConfig) {
+	passwordPolicy = policy
+}
+
 // CustomValidator holds custom validators
 type CustomValidator struct {
 	validate *validator.Validate
 }
 
-// NewValidator creates a new custom validator
-func NewValidator() *CustomValidator {
+// NewValidator creates a new custom validator. register adds the
+// validations of application types, which this package does not know.
+func NewValidator(register ...func(*validator.Validate)) *CustomValidator {
 	v := validator.New()
 
 	// Use JSON tag names in validation errors
-	v.RegisterTagNameFunc(func(fld reflect.StructField) string {
-		name := strings.SplitN(fld.Tag.Get("json"), ",", 2)[0]
-		if name == "-" {
-			return ""
-		}
-		return name
-	})
+	v.RegisterTagNameFunc(fieldName)
 
 	// Register custom validators here
-	// v.RegisterValidation("custom_tag", customValidationFunc)
+	v.RegisterValidation("strong_password", strongPassword)
+	for _, fn := range register {
+		fn(v)
+	}
 
 	return &CustomValidator{validate: v}
 }
@@ -37,64 +58,212 @@ func (cv *CustomValidator) Validate(i interface{}) error {
 	return cv.validate.Struct(i)
 }
 
-// RegisterGinValidator registers the custom validator with Gin
-func RegisterGinValidator() {
+// RegisterGinValidator registers the custom validator with Gin. register
+// adds the validations of application types, as for NewValidator.
+func RegisterGinValidator(register ...func(*validator.Validate)) {
 	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
 		// Use JSON tag names
-		v.RegisterTagNameFunc(func(fld reflect.StructField) string {
-			name := strings.SplitN(fld.Tag.Get("json"), ",", 2)[0]
-			if name == "-" {
-				return ""
-			}
-			return name
-		})
+		v.RegisterTagNameFunc(fieldName)
 
 		// Register custom validators
-		// v.RegisterValidation("custom_tag", customValidationFunc)
+		v.RegisterValidation("strong_password", strongPassword)
+		for _, fn := range register {
+			fn(v)
+		}
+	}
+}
+
+// fieldName names a field in validation errors by its JSON tag, or by its
+// form tag for query parameters
+func fieldName(fld reflect.StructField) string {
+	for _, tag := range []string{"json", "form"} {
+		name := strings.SplitN(fld.Tag.Get(tag), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+// EmailExistsFunc reports whether a user already has the email
+type EmailExistsFunc func(ctx context.Context, email string) (bool, error)
+
+// RegisterDBValidators registers validators that need database access with
+// Gin. emailExists backs the unique_email tag.
+func RegisterDBValidators(emailExists EmailExistsFunc) {
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterValidationCtx("unique_email", uniqueEmail(emailExists))
+	}
+}
+
+// uniqueEmail fails validation when a user with the email already exists.
+// Lookup errors pass validation and are left to the use case to handle.
+func uniqueEmail(emailExists EmailExistsFunc) validator.FuncCtx {
+	return func(ctx context.Context, fl validator.FieldLevel) bool {
+		ctx, cancel := context.WithTimeout(ctx, dbValidationTimeout)
+		defer cancel()
+
+		exists, err := emailExists(ctx, fl.Field().String())
+		return err != nil || !exists
+	}
+}
+
+// strongPassword checks a password against the configured policy
+func strongPassword(fl validator.FieldLevel) bool {
+	password := fl.Field().String()
+	if len([]rune(password)) < passwordPolicy.MinLength {
+		return false
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSpecial bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			hasSpecial = true
+		}
 	}
+
+	return (!passwordPolicy.RequireUpper || hasUpper) &&
+		(!passwordPolicy.RequireLower || hasLower) &&
+		(!passwordPolicy.RequireDigit || hasDigit) &&
+		(!passwordPolicy.RequireSpecial || hasSpecial)
 }
 
-// FormatValidationErrors formats validation errors to a map
-func FormatValidationErrors(err error) map[string]string {
+// passwordPolicyMessage describes the configured password policy in the given locale
+func passwordPolicyMessage(locale string) string {
+	var requirements []string
+	for _, rule := range []struct {
+		enabled bool
+		key     string
+	}{
+		{passwordPolicy.RequireUpper, "strong_password.upper"},
+		{passwordPolicy.RequireLower, "strong_password.lower"},
+		{passwordPolicy.RequireDigit, "strong_password.digit"},
+		{passwordPolicy.RequireSpecial, "strong_password.special"},
+	} {
+		if rule.enabled {
+			requirement, _ := translate(locale, rule.key)
+			requirements = append(requirements, requirement)
+		}
+	}
+
+	message, _ := translate(locale, "strong_password")
+	message = strings.ReplaceAll(message, "{param}", strconv.Itoa(passwordPolicy.MinLength))
+	if len(requirements) > 0 {
+		contain, _ := translate(locale, "strong_password.contain")
+		message += contain + strings.Join(requirements, ", ")
+	}
+	return message
+}
+
+// FormatValidationErrors formats validation errors to a map, with messages in
+// the given locale (see i18n.ParseLocale)
+func FormatValidationErrors(err error, locale string) map[string]string {
 	errors := make(map[string]string)
 
 	if validationErrors, ok := err.(validator.ValidationErrors); ok {
 		for _, e := range validationErrors {
-			errors[e.Field()] = getErrorMessage(e)
+			errors[e.Field()] = getErrorMessage(e, locale)
 		}
 	}
 
 	return errors
 }
 
+// FormatQueryErrors formats the error of binding query into obj. Unlike
+// FormatValidationErrors, it also reports parameters that could not be parsed
+// into their field's type, which fail binding before validation runs.
+func FormatQueryErrors(err error, query url.Values, obj any, locale string) map[string]string {
+	if _, ok := err.(validator.ValidationErrors); ok {
+		return FormatValidationErrors(err, locale)
+	}
+
+	errors := make(map[string]string)
+	typ := reflect.TypeOf(obj)
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if typ.Kind() == reflect.Struct {
+		collectQueryErrors(errors, typ, query, locale)
+	}
+	return errors
+}
+
+// collectQueryErrors binds each parameter of query alone into a new value of
+// typ, so the parameters gin fails to parse can be told apart
+func collectQueryErrors(errors map[string]string, typ reflect.Type, query url.Values, locale string) {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			collectQueryErrors(errors, field.Type, query, locale)
+			continue
+		}
+
+		name := strings.SplitN(field.Tag.Get("form"), ",", 2)[0]
+		values, ok := query[name]
+		if name == "" || name == "-" || !ok {
+			continue
+		}
+		probe := reflect.New(typ).Interface()
+		if binding.MapFormWithTag(probe, map[string][]string{name: values}, "form") != nil {
+			errors[name] = typeErrorMessage(field.Type, name, locale)
+		}
+	}
+}
+
+// typeErrorMessage returns the message for a value that is not of the type kind
+func typeErrorMessage(kind reflect.Type, field, locale string) string {
+	for kind.Kind() == reflect.Pointer || kind.Kind() == reflect.Slice {
+		kind = kind.Elem()
+	}
+
+	key := "default"
+	switch kind.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		key = "numeric"
+	case reflect.Bool:
+		key = "boolean"
+	}
+
+	message, _ := translate(locale, key)
+	return strings.ReplaceAll(message, "{field}", field)
+}
+
 // getErrorMessage returns a human-readable error message
-func getErrorMessage(fe validator.FieldError) string {
-	switch fe.Tag() {
-	case "required":
-		return "This field is required"
-	case "email":
-		return "Invalid email format"
-	case "min":
-		return "Value is too short"
-	case "max":
-		return "Value is too long"
-	case "gte":
-		return "Value must be greater than or equal to " + fe.Param()
-	case "lte":
-		return "Value must be less than or equal to " + fe.Param()
-	case "oneof":
-		return "Value must be one of: " + fe.Param()
-	case "url":
-		return "Invalid URL format"
-	case "uuid":
-		return "Invalid UUID format"
-	case "numeric":
-		return "Value must be numeric"
-	case "alpha":
-		return "Value must contain only letters"
-	case "alphanum":
-		return "Value must contain only letters and numbers"
-	default:
-		return "Invalid value for " + fe.Field()
+func getErrorMessage(fe validator.FieldError, locale string) string {
+	if fe.Tag() == "strong_password" {
+		return passwordPolicyMessage(locale)
+	}
+
+	tag := fe.Tag()
+	// min and max bound numbers by value rather than by length
+	switch fe.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		switch tag {
+		case "min":
+			tag = "gte"
+		case "max":
+			tag = "lte"
+		}
+	}
+
+	message, ok := translate(locale, tag)
+	if !ok {
+		message, _ = translate(locale, "default")
 	}
+
+	return strings.NewReplacer("{param}", fe.Param(), "{field}", fe.Field()).Replace(message)
 }